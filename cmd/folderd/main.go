@@ -0,0 +1,54 @@
+// Command folderd serves a folder driver over HTTP.
+//
+//	folderd -addr :8080 -data folders.json
+//
+// With -wal every mutation is written to folders.json.wal before it is applied
+// and compacted into folders.json every -compact-every records. Without it
+// folders.json is rewritten after every mutation.
+package main
+
+import (
+	"flag"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/georgechieng-sc/interns-2022/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	data := flag.String("data", "", "JSON or .csv file holding the folders, empty keeps folders in memory only")
+	wal := flag.Bool("wal", false, "persist through a write-ahead log instead of rewriting -data on every change")
+	compactEvery := flag.Int("compact-every", 1000, "log records between compactions when -wal is set")
+	verbose := flag.Bool("v", false, "log driver diagnostics to stderr")
+	flag.Parse()
+
+	opts := []folder.Option{}
+	if *verbose {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		folder.SetDefaultLogger(logger)
+		opts = append(opts, folder.WithLogger(logger))
+	}
+
+	driver, err := openDriver(*data, *wal, *compactEvery, opts)
+	if err != nil {
+		log.Fatalf("folderd: %v", err)
+	}
+
+	log.Printf("folderd: listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, server.New(driver)))
+}
+
+func openDriver(data string, wal bool, compactEvery int, opts []folder.Option) (folder.IDriver, error) {
+	switch {
+	case data == "":
+		return folder.NewDriverBuilder(opts...).Build()
+	case wal:
+		return folder.OpenLoggedDriver(data, compactEvery, opts...)
+	default:
+		return folder.OpenStoredDriver(folder.NewFileStore(data), true, opts...)
+	}
+}
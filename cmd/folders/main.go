@@ -0,0 +1,494 @@
+// Command folders queries and edits a folder tree stored in a JSON file in the sample.json format,
+// or in a CSV file with name, org_id and paths columns when the file name ends in .csv.
+//
+//	folders [-data file] <command> [flags] [args]
+//
+// Commands
+//
+//	ls       [-org id]                      list folders as JSON
+//	tree     [-org id] [-o text|dot|mermaid] [-highlight names] [name]
+//	                                        print folders as an indented tree, or as a Graphviz
+//	                                        or Mermaid diagram of one org with names highlighted
+//	children -org id [-o json|tree] name    list all descendents of a folder
+//	move     [-org id] name dst             move a folder, the org is inferred when -org is omitted
+//	mkdir    -org id [-parent name] name    create a folder
+//	rm       -org id [-r] name              delete a folder, -r deletes its subtree
+//	rename   -org id name newName           rename a folder
+//	validate                                check the data file loads into a driver
+//	init                                    create a new data file holding no folders
+//	generate [-seed n]                      write reproducible sample folders to a new data file
+//
+// Every command except init and generate needs an existing data file. Mutating commands write
+// it back. On failure the driver's error is printed to stderr and the exit status is non-zero.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// Returned for bad command lines, these exit with exitUsage
+var errUsage = errors.New("usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	global := flag.NewFlagSet("folders", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	dataFile := global.String("data", "folders.json", "JSON or .csv file holding the folders")
+	global.Usage = func() {
+		fmt.Fprintln(stderr, "usage: folders [-data file] <ls|tree|children|move|mkdir|rm|rename|validate|init|generate> [flags] [args]")
+		global.PrintDefaults()
+	}
+	if err := global.Parse(args); err != nil {
+		return exitUsage
+	}
+	if global.NArg() == 0 {
+		global.Usage()
+		return exitUsage
+	}
+
+	cmd := &command{
+		name:     global.Arg(0),
+		dataFile: *dataFile,
+		stdout:   stdout,
+		stderr:   stderr,
+	}
+	err := cmd.run(global.Args()[1:])
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage):
+		return exitUsage
+	default:
+		fmt.Fprintf(stderr, "folders %s: %v\n", cmd.name, err)
+		return exitError
+	}
+}
+
+type command struct {
+	name     string
+	dataFile string
+	stdout   io.Writer
+	stderr   io.Writer
+
+	// flags shared by the subcommands, each subcommand registers the ones it uses
+	flags     *flag.FlagSet
+	org       string
+	parent    string
+	recursive bool
+	output    string
+	highlight string
+	seed      int64
+}
+
+func (c *command) run(args []string) error {
+	c.flags = flag.NewFlagSet("folders "+c.name, flag.ContinueOnError)
+	c.flags.SetOutput(c.stderr)
+
+	switch c.name {
+	case "ls":
+		c.orgFlag()
+		return c.parse(args, 0, c.ls)
+	case "tree":
+		c.orgFlag()
+		c.flags.StringVar(&c.output, "o", "text", "output format, text, dot or mermaid")
+		c.flags.StringVar(&c.highlight, "highlight", "", "comma separated folder names to highlight in dot or mermaid output")
+		return c.parse(args, -1, c.tree)
+	case "children":
+		c.orgFlag()
+		c.flags.StringVar(&c.output, "o", "json", "output format, json or tree")
+		return c.parse(args, 1, c.children)
+	case "move":
+		c.orgFlag()
+		return c.parse(args, 2, c.move)
+	case "mkdir":
+		c.orgFlag()
+		c.flags.StringVar(&c.parent, "parent", "", "parent folder, empty creates a root folder")
+		return c.parse(args, 1, c.mkdir)
+	case "rm":
+		c.orgFlag()
+		c.flags.BoolVar(&c.recursive, "r", false, "delete the folder and its whole subtree")
+		return c.parse(args, 1, c.rm)
+	case "rename":
+		c.orgFlag()
+		return c.parse(args, 2, c.rename)
+	case "validate":
+		return c.parse(args, 0, c.validate)
+	case "init":
+		return c.parse(args, 0, c.init)
+	case "generate":
+		c.flags.Int64Var(&c.seed, "seed", 1, "seed of the generated folders, the same seed writes the same file")
+		return c.parse(args, 0, c.generate)
+	}
+	fmt.Fprintf(c.stderr, "folders: unknown command '%s'\n", c.name)
+	return errUsage
+}
+
+func (c *command) orgFlag() {
+	c.flags.StringVar(&c.org, "org", "", "org id")
+}
+
+// Parses flags and checks the number of positional args, -1 allows up to one
+func (c *command) parse(args []string, nArgs int, fn func(args []string) error) error {
+	if err := c.flags.Parse(args); err != nil {
+		return errUsage
+	}
+	rest := c.flags.Args()
+	if (nArgs >= 0 && len(rest) != nArgs) || (nArgs < 0 && len(rest) > 1) {
+		fmt.Fprintf(c.stderr, "folders %s: unexpected arguments %v\n", c.name, rest)
+		c.flags.Usage()
+		return errUsage
+	}
+	return fn(rest)
+}
+
+// Parses -org, it must be set when required
+func (c *command) orgID(required bool) (uuid.UUID, error) {
+	if c.org == "" {
+		if required {
+			return uuid.Nil, fmt.Errorf("-org is required")
+		}
+		return uuid.Nil, nil
+	}
+	orgID, err := uuid.FromString(c.org)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid org id '%s'", c.org)
+	}
+	return orgID, nil
+}
+
+// Loads the data file into a driver that writes every change back to it
+func (c *command) open() (*folder.StoredDriver, error) {
+	if err := c.checkDataFile(); err != nil {
+		return nil, err
+	}
+	return folder.OpenStoredDriver(folder.NewFileStore(c.dataFile), true)
+}
+
+// FileStore loads a missing file as empty, so a mistyped -data would otherwise list
+// nothing or create a new file
+func (c *command) checkDataFile() error {
+	if _, err := os.Stat(c.dataFile); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("data file '%s' does not exist, create one with init or generate", c.dataFile)
+		}
+		return err
+	}
+	return nil
+}
+
+// Returns the folders of orgID, or of every org if orgID is nil
+func (c *command) folders(driver folder.IDriver, orgID uuid.UUID) ([]folder.Folder, error) {
+	if orgID != uuid.Nil {
+		return driver.GetFoldersByOrgID(orgID), nil
+	}
+	return folder.NewFileStore(c.dataFile).Load()
+}
+
+func (c *command) ls(_ []string) error {
+	orgID, err := c.orgID(false)
+	if err != nil {
+		return err
+	}
+	driver, err := c.open()
+	if err != nil {
+		return err
+	}
+	folders, err := c.folders(driver, orgID)
+	if err != nil {
+		return err
+	}
+	sortByPath(folders)
+	return c.printJSON(folders)
+}
+
+func (c *command) tree(args []string) error {
+	if c.output != "text" {
+		return c.diagram(args)
+	}
+
+	orgID, err := c.orgID(len(args) == 1)
+	if err != nil {
+		return err
+	}
+	driver, err := c.open()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		subtree, err := subtree(driver, orgID, args[0])
+		if err != nil {
+			return err
+		}
+		printTree(c.stdout, subtree)
+		return nil
+	}
+
+	folders, err := c.folders(driver, orgID)
+	if err != nil {
+		return err
+	}
+	printTree(c.stdout, folders)
+	return nil
+}
+
+// Renders one org, or a subtree of it, as a dot or mermaid diagram
+func (c *command) diagram(args []string) error {
+	var render func(io.Writer, []folder.TreeNode, folder.RenderOptions) error
+	switch c.output {
+	case "dot":
+		render = folder.WriteDOT
+	case "mermaid":
+		render = folder.WriteMermaid
+	default:
+		return fmt.Errorf("unknown output format '%s', expected text, dot or mermaid", c.output)
+	}
+
+	orgID, err := c.orgID(true)
+	if err != nil {
+		return err
+	}
+	driver, err := c.open()
+	if err != nil {
+		return err
+	}
+
+	var nodes []folder.TreeNode
+	if len(args) == 1 {
+		node, err := driver.SubTree(orgID, args[0])
+		if err != nil {
+			return err
+		}
+		nodes = []folder.TreeNode{node}
+	} else if nodes, err = driver.BuildTree(orgID); err != nil {
+		return err
+	}
+
+	opts := folder.RenderOptions{}
+	if c.highlight != "" {
+		opts.Highlight = strings.Split(c.highlight, ",")
+	}
+	return render(c.stdout, nodes, opts)
+}
+
+func (c *command) children(args []string) error {
+	orgID, err := c.orgID(true)
+	if err != nil {
+		return err
+	}
+	driver, err := c.open()
+	if err != nil {
+		return err
+	}
+
+	children, err := driver.GetAllChildFolders(orgID, args[0])
+	if err != nil {
+		return err
+	}
+	switch c.output {
+	case "json":
+		sortByPath(children)
+		return c.printJSON(children)
+	case "tree":
+		printTree(c.stdout, children)
+		return nil
+	}
+	return fmt.Errorf("unknown output format '%s', expected json or tree", c.output)
+}
+
+func (c *command) move(args []string) error {
+	orgID, err := c.orgID(false)
+	if err != nil {
+		return err
+	}
+	driver, err := c.open()
+	if err != nil {
+		return err
+	}
+
+	var moved []folder.Folder
+	if orgID == uuid.Nil {
+		moved, err = driver.MoveFolder(args[0], args[1])
+	} else {
+		moved, err = driver.MoveFolderInOrg(orgID, args[0], args[1])
+	}
+	if err != nil {
+		return err
+	}
+	sortByPath(moved)
+	return c.printJSON(moved)
+}
+
+func (c *command) mkdir(args []string) error {
+	orgID, err := c.orgID(true)
+	if err != nil {
+		return err
+	}
+	driver, err := c.open()
+	if err != nil {
+		return err
+	}
+
+	created, err := driver.CreateFolder(orgID, c.parent, args[0])
+	if err != nil {
+		return err
+	}
+	return c.printJSON(created)
+}
+
+func (c *command) rm(args []string) error {
+	orgID, err := c.orgID(true)
+	if err != nil {
+		return err
+	}
+	driver, err := c.open()
+	if err != nil {
+		return err
+	}
+
+	removed, err := driver.DeleteFolder(orgID, args[0], folder.DeleteOptions{Recursive: c.recursive})
+	if err != nil {
+		return err
+	}
+	return c.printJSON(removed)
+}
+
+func (c *command) rename(args []string) error {
+	orgID, err := c.orgID(true)
+	if err != nil {
+		return err
+	}
+	driver, err := c.open()
+	if err != nil {
+		return err
+	}
+
+	renamed, err := driver.RenameFolder(orgID, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	return c.printJSON(renamed)
+}
+
+func (c *command) validate(_ []string) error {
+	if err := c.checkDataFile(); err != nil {
+		return err
+	}
+	builder := folder.NewDriverBuilder()
+	orgs := make(map[uuid.UUID]bool)
+	err := folder.NewFileStore(c.dataFile).Stream(func(f folder.Folder) error {
+		orgs[f.OrgId] = true
+		return builder.Add(f)
+	})
+	if err != nil {
+		return err
+	}
+	count := builder.Len()
+	if _, err := builder.Build(); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stdout, "%s: %d folders in %d orgs\n", c.dataFile, count, len(orgs))
+	return nil
+}
+
+func (c *command) init(_ []string) error {
+	return c.create([]folder.Folder{})
+}
+
+func (c *command) generate(_ []string) error {
+	folders, err := folder.Generate(folder.DefaultGeneratorConfig(c.seed))
+	if err != nil {
+		return err
+	}
+	return c.create(folders)
+}
+
+// Writes folders to a new data file
+func (c *command) create(folders []folder.Folder) error {
+	// never overwrite existing data
+	if _, err := os.Stat(c.dataFile); err == nil {
+		return fmt.Errorf("data file '%s' already exists", c.dataFile)
+	}
+	if err := folder.NewFileStore(c.dataFile).Save(folders); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stdout, "%s: wrote %d folders\n", c.dataFile, len(folders))
+	return nil
+}
+
+func (c *command) printJSON(v interface{}) error {
+	enc := json.NewEncoder(c.stdout)
+	enc.SetIndent("", "\t")
+	return enc.Encode(v)
+}
+
+// Returns the folder called name in orgID followed by its descendents
+func subtree(driver folder.IDriver, orgID uuid.UUID, name string) ([]folder.Folder, error) {
+	children, err := driver.GetAllChildFolders(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range driver.GetFoldersByOrgID(orgID) {
+		if f.Name == name {
+			return append([]folder.Folder{f}, children...), nil
+		}
+	}
+	return nil, fmt.Errorf("folder '%s' does not exist in org '%s'", name, orgID)
+}
+
+// Prints folders grouped by org, each folder indented below its closest listed ancestor
+func printTree(w io.Writer, folders []folder.Folder) {
+	sortByPath(folders)
+
+	var currOrg uuid.UUID
+	var ancestors []string
+	for _, f := range folders {
+		if f.OrgId != currOrg || ancestors == nil {
+			currOrg = f.OrgId
+			ancestors = []string{}
+			fmt.Fprintln(w, currOrg)
+		}
+		// pop listed folders that are not ancestors of f
+		for len(ancestors) > 0 && !strings.HasPrefix(f.Paths, ancestors[len(ancestors)-1]+".") {
+			ancestors = ancestors[:len(ancestors)-1]
+		}
+		fmt.Fprintf(w, "%s%s\n", strings.Repeat("  ", len(ancestors)+1), f.Name)
+		ancestors = append(ancestors, f.Paths)
+	}
+}
+
+// Sorts by org then by path label by label, so parents come directly before their children
+func sortByPath(folders []folder.Folder) {
+	sort.SliceStable(folders, func(i, j int) bool {
+		if folders[i].OrgId != folders[j].OrgId {
+			return folders[i].OrgId.String() < folders[j].OrgId.String()
+		}
+		a := strings.Split(folders[i].Paths, ".")
+		b := strings.Split(folders[j].Paths, ".")
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+}
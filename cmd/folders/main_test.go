@@ -0,0 +1,286 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func TestRun(t *testing.T) {
+	orgId1 := uuid.FromStringOrNil("11111111-1111-4111-8111-111111111111")
+	orgId2 := uuid.FromStringOrNil("22222222-2222-4222-8222-222222222222")
+	org1 := orgId1.String()
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantExit   int
+		wantStdout string
+		// substring expected in stdout or stderr when the exact output is not checked
+		wantContains string
+		// folders expected in org1 in the data file afterwards, nil skips the check
+		wantPaths []string
+	}{
+		// Functionalities
+		{
+			name:         "validate",
+			args:         []string{"validate"},
+			wantExit:     exitOK,
+			wantContains: "5 folders in 2 orgs",
+		},
+		{
+			name:     "tree of every org",
+			args:     []string{"tree"},
+			wantExit: exitOK,
+			wantStdout: org1 + "\n" +
+				"  alpha\n" +
+				"    bravo\n" +
+				"      charlie\n" +
+				"    delta\n" +
+				orgId2.String() + "\n" +
+				"  foxtrot\n",
+		},
+		{
+			name:     "tree of a subtree",
+			args:     []string{"tree", "-org", org1, "bravo"},
+			wantExit: exitOK,
+			wantStdout: org1 + "\n" +
+				"  bravo\n" +
+				"    charlie\n",
+		},
+		{
+			name:     "tree of a subtree as mermaid",
+			args:     []string{"tree", "-org", org1, "-o", "mermaid", "-highlight", "charlie", "bravo"},
+			wantExit: exitOK,
+			wantStdout: "flowchart TD\n" +
+				"    n0[\"bravo\"]\n" +
+				"    n1[\"charlie\"]\n" +
+				"    n0 --> n1\n" +
+				"    classDef highlight fill:#ffd966\n" +
+				"    class n1 highlight\n",
+		},
+		{
+			name:         "tree of an org as dot",
+			args:         []string{"tree", "-org", org1, "-o", "dot"},
+			wantExit:     exitOK,
+			wantContains: `"alpha.bravo" -> "alpha.bravo.charlie";`,
+		},
+		{
+			name:         "ls an org as JSON",
+			args:         []string{"ls", "-org", org1},
+			wantExit:     exitOK,
+			wantContains: `"paths": "alpha.bravo.charlie"`,
+		},
+		{
+			name:         "children as JSON",
+			args:         []string{"children", "-org", org1, "alpha"},
+			wantExit:     exitOK,
+			wantContains: `"name": "delta"`,
+		},
+		{
+			name:      "move",
+			args:      []string{"move", "bravo", "delta"},
+			wantExit:  exitOK,
+			wantPaths: []string{"alpha", "alpha.delta", "alpha.delta.bravo", "alpha.delta.bravo.charlie"},
+		},
+		{
+			name:      "mkdir",
+			args:      []string{"mkdir", "-org", org1, "-parent", "delta", "echo"},
+			wantExit:  exitOK,
+			wantPaths: []string{"alpha", "alpha.bravo", "alpha.bravo.charlie", "alpha.delta", "alpha.delta.echo"},
+		},
+		{
+			name:      "recursive rm",
+			args:      []string{"rm", "-org", org1, "-r", "bravo"},
+			wantExit:  exitOK,
+			wantPaths: []string{"alpha", "alpha.delta"},
+		},
+		{
+			name:      "rename",
+			args:      []string{"rename", "-org", org1, "bravo", "echo"},
+			wantExit:  exitOK,
+			wantPaths: []string{"alpha", "alpha.delta", "alpha.echo", "alpha.echo.charlie"},
+		},
+
+		// error checking
+		{
+			name:         "driver error exits non-zero",
+			args:         []string{"move", "bravo", "charlie"},
+			wantExit:     exitError,
+			wantContains: "folders move: moveFolder:",
+			wantPaths:    []string{"alpha", "alpha.bravo", "alpha.bravo.charlie", "alpha.delta"},
+		},
+		{
+			name:         "non-recursive rm of a folder with children",
+			args:         []string{"rm", "-org", org1, "bravo"},
+			wantExit:     exitError,
+			wantContains: "recursive",
+		},
+		{
+			name:         "missing required org",
+			args:         []string{"children", "alpha"},
+			wantExit:     exitError,
+			wantContains: "-org is required",
+		},
+		{
+			name:         "unknown tree output format",
+			args:         []string{"tree", "-org", org1, "-o", "svg"},
+			wantExit:     exitError,
+			wantContains: "unknown output format 'svg'",
+		},
+		{
+			name:         "ls with a missing data file",
+			args:         []string{"-data", "missing/folders.json", "ls"},
+			wantExit:     exitError,
+			wantContains: "data file 'missing/folders.json' does not exist",
+		},
+		{
+			name:         "mkdir with a missing data file",
+			args:         []string{"-data", "missing/folders.json", "mkdir", "-org", org1, "hotel"},
+			wantExit:     exitError,
+			wantContains: "does not exist",
+		},
+		{
+			name:         "init over an existing data file",
+			args:         []string{"init"},
+			wantExit:     exitError,
+			wantContains: "already exists",
+			wantPaths:    []string{"alpha", "alpha.bravo", "alpha.bravo.charlie", "alpha.delta"},
+		},
+		{
+			name:         "generate over an existing data file",
+			args:         []string{"generate"},
+			wantExit:     exitError,
+			wantContains: "already exists",
+			wantPaths:    []string{"alpha", "alpha.bravo", "alpha.bravo.charlie", "alpha.delta"},
+		},
+		{
+			name:     "wrong number of arguments",
+			args:     []string{"rename", "-org", org1, "bravo"},
+			wantExit: exitUsage,
+		},
+		{
+			name:     "unknown command",
+			args:     []string{"archive"},
+			wantExit: exitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dataFile := filepath.Join(t.TempDir(), "folders.json")
+			store := folder.NewFileStore(dataFile)
+			err := store.Save([]folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			exit := run(append([]string{"-data", dataFile}, tt.args...), stdout, stderr)
+
+			if exit != tt.wantExit {
+				t.Fatalf("run(%v) = %d, want %d, stderr %s", tt.args, exit, tt.wantExit, stderr)
+			}
+			if tt.wantStdout != "" {
+				if stdout.String() != tt.wantStdout {
+					t.Errorf("run(%v) printed\n%s\nwant\n%s", tt.args, stdout, tt.wantStdout)
+				}
+			}
+			if tt.wantContains != "" && !strings.Contains(stdout.String()+stderr.String(), tt.wantContains) {
+				t.Errorf("run(%v) output does not contain %q, stdout %s stderr %s", tt.args, tt.wantContains, stdout, stderr)
+			}
+
+			if tt.wantPaths != nil {
+				folders, err := store.Load()
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := []string{}
+				for _, f := range folders {
+					if f.OrgId == orgId1 {
+						got = append(got, f.Paths)
+					}
+				}
+				sort.Strings(got)
+				if strings.Join(got, ",") != strings.Join(tt.wantPaths, ",") {
+					t.Errorf("data file holds paths %v, want %v", got, tt.wantPaths)
+				}
+			}
+		})
+	}
+}
+
+func TestRun_Generate(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "folders.json")
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if exit := run([]string{"-data", dataFile, "generate", "-seed", "7"}, stdout, stderr); exit != exitOK {
+		t.Fatalf("generate exited %d, stderr %s", exit, stderr)
+	}
+	first, err := folder.NewFileStore(dataFile).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), fmt.Sprintf("wrote %d folders", len(first))) {
+		t.Errorf("generate printed %q, want the number of folders written", stdout)
+	}
+
+	// the generated file is usable by every other command
+	if exit := run([]string{"-data", dataFile, "validate"}, stdout, stderr); exit != exitOK {
+		t.Fatalf("validate of a generated file exited %d, stderr %s", exit, stderr)
+	}
+
+	// the same seed writes the same folders
+	again := filepath.Join(t.TempDir(), "folders.json")
+	if exit := run([]string{"-data", again, "generate", "-seed", "7"}, stdout, stderr); exit != exitOK {
+		t.Fatalf("generate exited %d, stderr %s", exit, stderr)
+	}
+	second, err := folder.NewFileStore(again).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("generate with the same seed wrote different folders")
+	}
+}
+
+func TestRun_Init(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+
+	for _, name := range []string{"folders.json", "folders.jsonl", "folders.csv"} {
+		t.Run(name, func(t *testing.T) {
+			dataFile := filepath.Join(t.TempDir(), name)
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			if exit := run([]string{"-data", dataFile, "init"}, stdout, stderr); exit != exitOK {
+				t.Fatalf("init exited %d, stderr %s", exit, stderr)
+			}
+
+			// an empty tree is started with mkdir
+			if exit := run([]string{"-data", dataFile, "mkdir", "-org", orgId.String(), "alpha"}, stdout, stderr); exit != exitOK {
+				t.Fatalf("mkdir after init exited %d, stderr %s", exit, stderr)
+			}
+			folders, err := folder.NewFileStore(dataFile).Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(folders) != 1 || folders[0].Paths != "alpha" {
+				t.Errorf("data file after init and mkdir holds %v, want alpha", folders)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package folder
+
+import (
+	"fmt"
+
+	"github.com/georgechieng-sc/interns-2022/ltree"
+	"github.com/gofrs/uuid"
+)
+
+// Reports whether a is b or an ancestor of b within orgID, following ltree's @> operator
+func (d *driver) IsAncestor(orgID uuid.UUID, a string, b string) (bool, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if orgID == uuid.Nil {
+		return false, invalidOrgIDError("isAncestor", orgID)
+	}
+
+	ancestor, err := d.getFolderInOrg(orgID, a)
+	if err != nil {
+		return false, fmt.Errorf("isAncestor: %w", err)
+	}
+	descendent, err := d.getFolderInOrg(orgID, b)
+	if err != nil {
+		return false, fmt.Errorf("isAncestor: %w", err)
+	}
+
+	return ltree.IsAncestor(ancestor.Paths, descendent.Paths), nil
+}
+
+// Returns the deepest folder whose path is a prefix of every named folder's path.
+// Unlike ltree's lca a folder counts as its own ancestor, so the result may be one of names.
+func (d *driver) LowestCommonAncestor(orgID uuid.UUID, names ...string) (Folder, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if orgID == uuid.Nil {
+		return Folder{}, invalidOrgIDError("lowestCommonAncestor", orgID)
+	}
+	if len(names) == 0 {
+		return Folder{}, &FolderError{Err: ErrInvalidName, OrgID: orgID, msg: "lowestCommonAncestor: at least one folder name is required"}
+	}
+
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		folder, err := d.getFolderInOrg(orgID, name)
+		if err != nil {
+			return Folder{}, fmt.Errorf("lowestCommonAncestor: %w", err)
+		}
+		paths = append(paths, folder.Paths)
+	}
+
+	// folders under different roots share no ancestor
+	prefix := ltree.CommonPrefix(paths...)
+	ancestor, found := d.pathIndex[pathKey{orgID, prefix}]
+	if !found {
+		return Folder{}, &FolderError{
+			Err:   ErrFolderNotFound,
+			OrgID: orgID,
+			msg:   fmt.Sprintf("lowestCommonAncestor: folders %v share no common ancestor in org '%s'", names, orgID),
+		}
+	}
+
+	return *ancestor, nil
+}
+
+// Returns the folders from the root of name's tree down to name itself, following Parent pointers
+func (d *driver) GetAncestors(orgID uuid.UUID, name string) ([]Folder, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if orgID == uuid.Nil {
+		return nil, invalidOrgIDError("getAncestors", orgID)
+	}
+
+	folder, err := d.getFolderInOrg(orgID, name)
+	if err != nil {
+		return nil, fmt.Errorf("getAncestors: %w", err)
+	}
+
+	// walk up to the root, then reverse so the chain reads root first
+	chain := []Folder{}
+	for curr := folder; curr != nil; curr = curr.Parent {
+		chain = append(chain, *curr)
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+
+	return chain, nil
+}
+
+// A single entry of a breadcrumb trail
+type Crumb struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// Converts a root first chain of folders, as returned by GetAncestors, into breadcrumbs
+func Breadcrumb(ancestors []Folder) []Crumb {
+	crumbs := make([]Crumb, 0, len(ancestors))
+	for _, folder := range ancestors {
+		crumbs = append(crumbs, Crumb{Name: folder.Name, Path: folder.Paths})
+	}
+	return crumbs
+}
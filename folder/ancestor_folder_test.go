@@ -0,0 +1,221 @@
+package folder_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func newAncestorTestDriver(t *testing.T, orgId1, orgId2 uuid.UUID) folder.IDriver {
+	t.Helper()
+	driver, err := folder.NewDriver([]folder.Folder{
+		{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+		{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+		{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+		{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+		{Name: "echo", Paths: "echo", OrgId: orgId1},
+		{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+	})
+	if err != nil {
+		t.Fatalf("driver failed to initialise - %v", err)
+	}
+	return driver
+}
+
+func Test_folder_IsAncestor(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name         string
+		orgID        uuid.UUID
+		a, b         string
+		want         bool
+		wantRunError bool
+	}{
+		{name: "parent of child", orgID: orgId1, a: "alpha", b: "bravo", want: true},
+		{name: "grandparent of grandchild", orgID: orgId1, a: "alpha", b: "charlie", want: true},
+		{name: "folder is its own ancestor", orgID: orgId1, a: "bravo", b: "bravo", want: true},
+		{name: "child is not ancestor of parent", orgID: orgId1, a: "charlie", b: "alpha", want: false},
+		{name: "siblings", orgID: orgId1, a: "bravo", b: "delta", want: false},
+		{name: "separate roots", orgID: orgId1, a: "alpha", b: "echo", want: false},
+		{name: "folder in another org", orgID: orgId1, a: "alpha", b: "foxtrot", wantRunError: true},
+		{name: "missing folder", orgID: orgId1, a: "alpha", b: "golf", wantRunError: true},
+		{name: "invalid orgID", orgID: uuid.Nil, a: "alpha", b: "bravo", wantRunError: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver := newAncestorTestDriver(t, orgId1, orgId2)
+
+			got, err := driver.IsAncestor(tt.orgID, tt.a, tt.b)
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("IsAncestor() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsAncestor(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_folder_LowestCommonAncestor(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name         string
+		orgID        uuid.UUID
+		names        []string
+		want         folder.Folder
+		wantRunError bool
+	}{
+		{
+			name:  "siblings",
+			orgID: orgId1,
+			names: []string{"bravo", "delta"},
+			want:  folder.Folder{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+		},
+		{
+			name:  "cousins at different depths",
+			orgID: orgId1,
+			names: []string{"charlie", "delta"},
+			want:  folder.Folder{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+		},
+		{
+			name:  "ancestor and descendent",
+			orgID: orgId1,
+			names: []string{"bravo", "charlie"},
+			want:  folder.Folder{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+		},
+		{
+			name:  "single folder",
+			orgID: orgId1,
+			names: []string{"charlie"},
+			want:  folder.Folder{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+		},
+		{
+			name:         "separate roots",
+			orgID:        orgId1,
+			names:        []string{"charlie", "echo"},
+			wantRunError: true,
+		},
+		{
+			name:         "folder in another org",
+			orgID:        orgId1,
+			names:        []string{"charlie", "foxtrot"},
+			wantRunError: true,
+		},
+		{
+			name:         "no folders",
+			orgID:        orgId1,
+			wantRunError: true,
+		},
+		{
+			name:         "invalid orgID",
+			orgID:        uuid.Nil,
+			names:        []string{"bravo"},
+			wantRunError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver := newAncestorTestDriver(t, orgId1, orgId2)
+
+			got, err := driver.LowestCommonAncestor(tt.orgID, tt.names...)
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("LowestCommonAncestor() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if tt.wantRunError {
+				return
+			}
+			if !compareFolders([]folder.Folder{got}, []folder.Folder{tt.want}) {
+				t.Errorf("LowestCommonAncestor(%v) = %v, want %v", tt.names, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_folder_GetAncestors(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name         string
+		orgID        uuid.UUID
+		folderName   string
+		want         []folder.Crumb
+		wantRunError bool
+	}{
+		{
+			name:       "nested folder",
+			orgID:      orgId1,
+			folderName: "charlie",
+			want: []folder.Crumb{
+				{Name: "alpha", Path: "alpha"},
+				{Name: "bravo", Path: "alpha.bravo"},
+				{Name: "charlie", Path: "alpha.bravo.charlie"},
+			},
+		},
+		{
+			name:       "root folder",
+			orgID:      orgId1,
+			folderName: "alpha",
+			want: []folder.Crumb{
+				{Name: "alpha", Path: "alpha"},
+			},
+		},
+		{
+			name:         "folder in another org",
+			orgID:        orgId1,
+			folderName:   "foxtrot",
+			wantRunError: true,
+		},
+		{
+			name:         "missing folder",
+			orgID:        orgId1,
+			folderName:   "golf",
+			wantRunError: true,
+		},
+		{
+			name:         "invalid orgID",
+			orgID:        uuid.Nil,
+			folderName:   "alpha",
+			wantRunError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver := newAncestorTestDriver(t, orgId1, orgId2)
+
+			got, err := driver.GetAncestors(tt.orgID, tt.folderName)
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("GetAncestors() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if tt.wantRunError {
+				return
+			}
+			for _, f := range got {
+				if f.OrgId != tt.orgID {
+					t.Errorf("GetAncestors() returned %v from org %s, want org %s", f.Name, f.OrgId, tt.orgID)
+				}
+			}
+			if crumbs := folder.Breadcrumb(got); !reflect.DeepEqual(crumbs, tt.want) {
+				t.Errorf("Breadcrumb(GetAncestors()) = %v, want %v", crumbs, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package folder
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+)
+
+// Builds a driver one folder at a time so large inputs never have to sit in a
+// []Folder first. Folders may be added in any order, parents are linked by Build.
+type DriverBuilder struct {
+	d *driver
+}
+
+func NewDriverBuilder(opts ...Option) *DriverBuilder {
+	d := &driver{
+		folders:    []*Folder{},
+		pathIndex:  make(map[pathKey]*Folder),
+		nameIndex:  make(map[string][]*Folder),
+		orgIdIndex: make(map[uuid.UUID][]*Folder),
+		logger:     defaultLogger,
+		// mutex lock does not require explicit initialisation
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return &DriverBuilder{d: d}
+}
+
+// Number of folders added so far
+func (b *DriverBuilder) Len() int {
+	return len(b.d.folders)
+}
+
+// Adds a folder, rejecting a name already used in its org or a path that repeats a name
+func (b *DriverBuilder) Add(folder Folder) error {
+	f := folder
+	f.Parent = nil
+	f.Children = nil
+
+	// Check for duplicate folder names within the same OrgId
+	for _, existingFolder := range b.d.nameIndex[f.Name] {
+		if existingFolder.OrgId == f.OrgId {
+			return duplicateNameError("newDriver", f.Name, f.OrgId)
+		}
+	}
+
+	// Check for cycles
+	if hasRepeats(f.Paths) {
+		return &FolderError{
+			Err:   ErrCycle,
+			Name:  f.Name,
+			OrgID: f.OrgId,
+			Path:  f.Paths,
+			msg:   fmt.Sprintf("newDriver: cannot instantiate path %s has it will create a cycle", f.Paths),
+		}
+	}
+
+	b.d.folders = append(b.d.folders, &f)
+	b.d.pathIndex[pathKey{f.OrgId, f.Paths}] = &f
+	b.d.nameIndex[f.Name] = append(b.d.nameIndex[f.Name], &f)
+	b.d.orgIdIndex[f.OrgId] = append(b.d.orgIdIndex[f.OrgId], &f)
+	return nil
+}
+
+// Links every folder to its parent and returns the driver, the builder must not be used afterwards
+func (b *DriverBuilder) Build() (IDriver, error) {
+	// folderDriver.folders stores a slice of *Folder
+	for _, folder := range b.d.folders {
+		// populate child and parent
+		// obtain parent and set children
+		parentPath := getParentPath(folder.Paths)
+		// root path
+		if parentPath == "" {
+			continue
+		}
+		parentFolder, found := b.d.pathIndex[pathKey{folder.OrgId, parentPath}]
+		if !found {
+			return nil, &FolderError{
+				Err:   ErrFolderNotFound,
+				Name:  folder.Name,
+				OrgID: folder.OrgId,
+				Path:  parentPath,
+				msg:   fmt.Sprintf("newDriver: Parent oath '%s' not found for folder '%s'", parentPath, folder.Name),
+			}
+		}
+
+		// establish parent child
+		parentFolder.Children = append(parentFolder.Children, folder)
+		folder.Parent = parentFolder
+	}
+
+	d := b.d
+	b.d = nil
+	return d, nil
+}
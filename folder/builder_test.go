@@ -0,0 +1,92 @@
+package folder_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_DriverBuilder(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name    string
+		folders []folder.Folder
+		// error from Add of the last folder, or from Build if nil
+		wantAddErr   error
+		wantBuildErr error
+	}{
+		{
+			name: "children before their parents",
+			folders: []folder.Folder{
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "alpha", Paths: "alpha", OrgId: orgId2},
+			},
+		},
+		{
+			name: "duplicate name in an org",
+			folders: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "alpha", Paths: "bravo.alpha", OrgId: orgId1},
+			},
+			wantAddErr: folder.ErrDuplicateName,
+		},
+		{
+			name: "path repeating a name",
+			folders: []folder.Folder{
+				{Name: "alpha", Paths: "alpha.bravo.alpha", OrgId: orgId1},
+			},
+			wantAddErr: folder.ErrCycle,
+		},
+		{
+			name: "missing parent",
+			folders: []folder.Folder{
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+			},
+			wantBuildErr: folder.ErrFolderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			builder := folder.NewDriverBuilder()
+			var addErr error
+			for _, f := range tt.folders {
+				if addErr = builder.Add(f); addErr != nil {
+					break
+				}
+			}
+			if tt.wantAddErr != nil || addErr != nil {
+				if !errors.Is(addErr, tt.wantAddErr) {
+					t.Errorf("Add() error = %v, want %v", addErr, tt.wantAddErr)
+				}
+				return
+			}
+			if builder.Len() != len(tt.folders) {
+				t.Errorf("Len() = %d, want %d", builder.Len(), len(tt.folders))
+			}
+
+			driver, err := builder.Build()
+			if tt.wantBuildErr != nil || err != nil {
+				if !errors.Is(err, tt.wantBuildErr) {
+					t.Errorf("Build() error = %v, want %v", err, tt.wantBuildErr)
+				}
+				return
+			}
+			children, err := driver.GetAllChildFolders(orgId1, "alpha")
+			if err != nil {
+				t.Fatalf("GetAllChildFolders() received unexpected error: %v", err)
+			}
+			if want := tt.folders[:2]; !compareFolders(children, want) {
+				t.Errorf("GetAllChildFolders() = %v, want %v", children, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package folder
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/gofrs/uuid"
+	"github.com/lucasepe/codename"
+)
+
+// Generates the name of a copied folder from its original name.
+// taken reports whether a candidate name is already used in the target org.
+type NamingFunc func(name string, taken func(string) bool) string
+
+// Names copies by appending suffix, then suffix followed by a counter
+// e.g. "bravo_copy", "bravo_copy2", "bravo_copy3"
+func SuffixNaming(suffix string) NamingFunc {
+	return func(name string, taken func(string) bool) string {
+		candidate := name + suffix
+		for i := 2; taken(candidate); i++ {
+			candidate = fmt.Sprintf("%s%s%d", name, suffix, i)
+		}
+		return candidate
+	}
+}
+
+// Names copies with random codenames, the same generator GenerateData uses
+func CodenameNaming(rng *rand.Rand) NamingFunc {
+	return func(_ string, taken func(string) bool) string {
+		candidate := codename.Generate(rng, 0)
+		for taken(candidate) {
+			candidate = codename.Generate(rng, 0)
+		}
+		return candidate
+	}
+}
+
+// Deep copies the subtree rooted at src under dst within orgID, an empty dst copies to the root.
+// Every copied folder is renamed through namingFn, SuffixNaming("_copy") is used if namingFn is nil.
+// Returns the new folders, parents before children.
+func (d *driver) CopyFolder(orgID uuid.UUID, src string, dst string, namingFn NamingFunc) ([]Folder, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if orgID == uuid.Nil {
+		return nil, invalidOrgIDError("copyFolder", orgID)
+	}
+	if namingFn == nil {
+		namingFn = SuffixNaming("_copy")
+	}
+
+	srcFolder, err := d.getFolderInOrg(orgID, src)
+	if err != nil {
+		return nil, fmt.Errorf("copyFolder: source %w", err)
+	}
+
+	var dstFolder *Folder
+	if dst != "" {
+		dstFolder, err = d.getFolderInOrg(orgID, dst)
+		if err != nil {
+			return nil, fmt.Errorf("copyFolder: destination %w", err)
+		}
+	}
+
+	// names handed out during this copy are taken as well
+	assigned := make(map[string]bool)
+	taken := func(name string) bool {
+		if assigned[name] {
+			return true
+		}
+		_, err := d.getFolderInOrg(orgID, name)
+		return err == nil
+	}
+
+	// build the copy before touching the driver so a bad name leaves it unchanged
+	var copyTree func(original *Folder, parent *Folder) (*Folder, error)
+	copyTree = func(original *Folder, parent *Folder) (*Folder, error) {
+		newName := namingFn(original.Name, taken)
+		if err := validateName(newName); err != nil {
+			return nil, err
+		}
+		if taken(newName) {
+			return nil, &FolderError{
+				Err:   ErrDuplicateName,
+				Name:  newName,
+				OrgID: orgID,
+				msg:   fmt.Sprintf("generated name '%s' already exists in org '%s'", newName, orgID),
+			}
+		}
+		assigned[newName] = true
+
+		newPath := newName
+		if parent != nil {
+			newPath = fmt.Sprintf("%s.%s", parent.Paths, newName)
+		}
+		newFolder := &Folder{
+			Name:   newName,
+			OrgId:  orgID,
+			Paths:  newPath,
+			Parent: parent,
+		}
+		for _, child := range original.Children {
+			newChild, err := copyTree(child, newFolder)
+			if err != nil {
+				return nil, err
+			}
+			newFolder.Children = append(newFolder.Children, newChild)
+		}
+		return newFolder, nil
+	}
+
+	newRoot, err := copyTree(srcFolder, dstFolder)
+	if err != nil {
+		return nil, fmt.Errorf("copyFolder: %w", err)
+	}
+	if dstFolder != nil {
+		dstFolder.Children = append(dstFolder.Children, newRoot)
+	}
+
+	// index the copied folders, parents before children
+	created := []Folder{}
+	var index func(*Folder)
+	index = func(currFolder *Folder) {
+		d.folders = append(d.folders, currFolder)
+		d.pathIndex[pathKey{orgID, currFolder.Paths}] = currFolder
+		d.nameIndex[currFolder.Name] = append(d.nameIndex[currFolder.Name], currFolder)
+		d.orgIdIndex[orgID] = append(d.orgIdIndex[orgID], currFolder)
+		created = append(created, *currFolder)
+		for _, child := range currFolder.Children {
+			index(child)
+		}
+	}
+	index(newRoot)
+
+	return created, nil
+}
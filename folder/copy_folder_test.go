@@ -0,0 +1,207 @@
+package folder_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_CopyFolder(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name         string
+		orgID        uuid.UUID
+		source       string
+		destination  string
+		namingFn     folder.NamingFunc
+		want         []folder.Folder
+		wantRunError bool
+	}{
+		// Functionalities
+		{
+			name:        "copy leaf folder into another folder",
+			orgID:       orgId1,
+			source:      "charlie",
+			destination: "delta",
+			namingFn:    folder.SuffixNaming("_copy"),
+			want: []folder.Folder{
+				{Name: "charlie_copy", Paths: "alpha.delta.charlie_copy", OrgId: orgId1},
+			},
+		},
+		{
+			name:        "copy folder with children into another folder",
+			orgID:       orgId1,
+			source:      "bravo",
+			destination: "delta",
+			namingFn:    folder.SuffixNaming("_copy"),
+			want: []folder.Folder{
+				{Name: "bravo_copy", Paths: "alpha.delta.bravo_copy", OrgId: orgId1},
+				{Name: "charlie_copy", Paths: "alpha.delta.bravo_copy.charlie_copy", OrgId: orgId1},
+			},
+		},
+		{
+			name:        "copy folder into its own subtree",
+			orgID:       orgId1,
+			source:      "bravo",
+			destination: "charlie",
+			namingFn:    folder.SuffixNaming("_copy"),
+			want: []folder.Folder{
+				{Name: "bravo_copy", Paths: "alpha.bravo.charlie.bravo_copy", OrgId: orgId1},
+				{Name: "charlie_copy", Paths: "alpha.bravo.charlie.bravo_copy.charlie_copy", OrgId: orgId1},
+			},
+		},
+		{
+			name:        "copy folder to the root",
+			orgID:       orgId1,
+			source:      "bravo",
+			destination: "",
+			namingFn:    folder.SuffixNaming("_copy"),
+			want: []folder.Folder{
+				{Name: "bravo_copy", Paths: "bravo_copy", OrgId: orgId1},
+				{Name: "charlie_copy", Paths: "bravo_copy.charlie_copy", OrgId: orgId1},
+			},
+		},
+		{
+			name:        "suffix naming skips names that are already taken",
+			orgID:       orgId1,
+			source:      "delta",
+			destination: "alpha",
+			namingFn:    folder.SuffixNaming("_copy"),
+			want: []folder.Folder{
+				{Name: "delta_copy2", Paths: "alpha.delta_copy2", OrgId: orgId1},
+			},
+		},
+		{
+			name:        "nil naming function defaults to suffix naming",
+			orgID:       orgId1,
+			source:      "charlie",
+			destination: "alpha",
+			namingFn:    nil,
+			want: []folder.Folder{
+				{Name: "charlie_copy", Paths: "alpha.charlie_copy", OrgId: orgId1},
+			},
+		},
+
+		// edge cases and error checking
+		{
+			name:        "error when naming function returns an existing name",
+			orgID:       orgId1,
+			source:      "charlie",
+			destination: "alpha",
+			namingFn: func(name string, _ func(string) bool) string {
+				return name
+			},
+			wantRunError: true,
+		},
+		{
+			name:        "error when naming function returns a name containing '.'",
+			orgID:       orgId1,
+			source:      "charlie",
+			destination: "alpha",
+			namingFn: func(name string, _ func(string) bool) string {
+				return name + ".copy"
+			},
+			wantRunError: true,
+		},
+		{
+			name:         "error copying to a destination in a different org",
+			orgID:        orgId1,
+			source:       "bravo",
+			destination:  "foxtrot",
+			wantRunError: true,
+		},
+		{
+			name:         "error copying non-existent source",
+			orgID:        orgId1,
+			source:       "invalid_folder",
+			destination:  "alpha",
+			wantRunError: true,
+		},
+		{
+			name:         "error copying with invalid orgID",
+			orgID:        uuid.Nil,
+			source:       "bravo",
+			destination:  "alpha",
+			wantRunError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			initialFolders := []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+				{Name: "delta_copy", Paths: "alpha.delta_copy", OrgId: orgId1},
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+			}
+			driver, err := folder.NewDriver(initialFolders)
+			if err != nil {
+				t.Fatalf("driver failed to initialise - %v", err)
+			}
+
+			got, err := driver.CopyFolder(tt.orgID, tt.source, tt.destination, tt.namingFn)
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("CopyFolder() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if tt.wantRunError {
+				// a failed copy must leave the driver unchanged
+				if remaining := driver.GetFoldersByOrgID(orgId1); len(remaining) != 5 {
+					t.Errorf("GetFoldersByOrgID() after failed copy returned %d folders, want 5", len(remaining))
+				}
+				return
+			}
+
+			if !compareFolders(got, tt.want) {
+				t.Errorf("CopyFolder() = %v, want %v", got, tt.want)
+			}
+
+			// the copies are indexed like any other folder
+			for _, f := range tt.want {
+				if _, err := driver.GetFolderByPath(tt.orgID, f.Paths); err != nil {
+					t.Errorf("GetFolderByPath() for copied folder received unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func Test_folder_CopyFolder_CodenameNaming(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+
+	driver, err := folder.NewDriver([]folder.Folder{
+		{Name: "alpha", Paths: "alpha", OrgId: orgId},
+		{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId},
+		{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId},
+	})
+	if err != nil {
+		t.Fatalf("driver failed to initialise - %v", err)
+	}
+
+	got, err := driver.CopyFolder(orgId, "alpha", "", folder.CodenameNaming(rand.New(rand.NewSource(1))))
+	if err != nil {
+		t.Fatalf("CopyFolder() received unexpected error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("CopyFolder() returned %d folders, want 3", len(got))
+	}
+
+	if len(driver.GetFoldersByOrgID(orgId)) != 6 {
+		t.Errorf("GetFoldersByOrgID() after copy returned %d folders, want 6", len(driver.GetFoldersByOrgID(orgId)))
+	}
+	children, err := driver.GetAllChildFolders(orgId, got[0].Name)
+	if err != nil {
+		t.Fatalf("GetAllChildFolders() received unexpected error: %v", err)
+	}
+	if len(children) != 2 {
+		t.Errorf("GetAllChildFolders() on copied root returned %d folders, want 2", len(children))
+	}
+}
@@ -0,0 +1,54 @@
+package folder
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+)
+
+// Creates a new folder called name under parentName within orgID.
+// An empty parentName creates a root folder.
+func (d *driver) CreateFolder(orgID uuid.UUID, parentName string, name string) (Folder, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if orgID == uuid.Nil {
+		return Folder{}, invalidOrgIDError("createFolder", orgID)
+	}
+	if err := validateName(name); err != nil {
+		return Folder{}, fmt.Errorf("createFolder: %w", err)
+	}
+
+	// (orgId, name) must remain unique, same rule as NewDriver
+	if _, err := d.getFolderInOrg(orgID, name); err == nil {
+		return Folder{}, duplicateNameError("createFolder", name, orgID)
+	}
+
+	var parentFolder *Folder
+	path := name
+	if parentName != "" {
+		p, err := d.getFolderInOrg(orgID, parentName)
+		if err != nil {
+			return Folder{}, fmt.Errorf("createFolder: parent %w", err)
+		}
+		parentFolder = p
+		path = fmt.Sprintf("%s.%s", parentFolder.Paths, name)
+	}
+
+	newFolder := &Folder{
+		Name:   name,
+		OrgId:  orgID,
+		Paths:  path,
+		Parent: parentFolder,
+	}
+	if parentFolder != nil {
+		parentFolder.Children = append(parentFolder.Children, newFolder)
+	}
+
+	d.folders = append(d.folders, newFolder)
+	d.pathIndex[pathKey{orgID, newFolder.Paths}] = newFolder
+	d.nameIndex[name] = append(d.nameIndex[name], newFolder)
+	d.orgIdIndex[orgID] = append(d.orgIdIndex[orgID], newFolder)
+
+	return *newFolder, nil
+}
@@ -0,0 +1,132 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_CreateFolder(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name         string
+		orgID        uuid.UUID
+		parent       string
+		folderName   string
+		want         folder.Folder
+		wantRunError bool
+	}{
+		// Functionalities
+		{
+			name:       "create root folder",
+			orgID:      orgId1,
+			parent:     "",
+			folderName: "hotel",
+			want:       folder.Folder{Name: "hotel", Paths: "hotel", OrgId: orgId1},
+		},
+		{
+			name:       "create folder under a leaf folder",
+			orgID:      orgId1,
+			parent:     "charlie",
+			folderName: "hotel",
+			want:       folder.Folder{Name: "hotel", Paths: "alpha.bravo.charlie.hotel", OrgId: orgId1},
+		},
+		{
+			name:       "create folder with a name that exists in a different org",
+			orgID:      orgId2,
+			parent:     "foxtrot",
+			folderName: "alpha",
+			want:       folder.Folder{Name: "alpha", Paths: "foxtrot.alpha", OrgId: orgId2},
+		},
+
+		// edge cases and error checking
+		{
+			name:         "error creating folder with duplicate name in the same org",
+			orgID:        orgId1,
+			parent:       "alpha",
+			folderName:   "charlie",
+			wantRunError: true,
+		},
+		{
+			name:         "error creating folder under a parent in a different org",
+			orgID:        orgId1,
+			parent:       "foxtrot",
+			folderName:   "hotel",
+			wantRunError: true,
+		},
+		{
+			name:         "error creating folder under non-existent parent",
+			orgID:        orgId1,
+			parent:       "invalid_folder",
+			folderName:   "hotel",
+			wantRunError: true,
+		},
+		{
+			name:         "error creating folder with a '.' in its name",
+			orgID:        orgId1,
+			parent:       "alpha",
+			folderName:   "hotel.india",
+			wantRunError: true,
+		},
+		{
+			name:         "error creating folder with empty name",
+			orgID:        orgId1,
+			parent:       "alpha",
+			folderName:   "",
+			wantRunError: true,
+		},
+		{
+			name:         "error creating folder with invalid orgID",
+			orgID:        uuid.Nil,
+			parent:       "",
+			folderName:   "hotel",
+			wantRunError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := folder.NewDriver([]folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+			})
+			if err != nil {
+				t.Fatalf("driver failed to initialise - %v", err)
+			}
+
+			got, err := driver.CreateFolder(tt.orgID, tt.parent, tt.folderName)
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("CreateFolder() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if tt.wantRunError {
+				return
+			}
+
+			if !compareFolders([]folder.Folder{got}, []folder.Folder{tt.want}) {
+				t.Errorf("CreateFolder() = %v, want %v", got, tt.want)
+			}
+
+			// the new folder must be reachable through the driver's lookups
+			if tt.parent != "" {
+				children, err := driver.GetAllChildFolders(tt.orgID, tt.parent)
+				if err != nil {
+					t.Fatalf("GetAllChildFolders() received unexpected error: %v", err)
+				}
+				if !containsFolder(children, tt.want) {
+					t.Errorf("GetAllChildFolders() = %v, expected it to contain %v", children, tt.want)
+				}
+			}
+			if !containsFolder(driver.GetFoldersByOrgID(tt.orgID), tt.want) {
+				t.Errorf("GetFoldersByOrgID() does not contain %v", tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package folder
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/georgechieng-sc/interns-2022/ltree"
+	"github.com/gofrs/uuid"
+)
+
+// Columns of a folder CSV file, the header row may list them in any order
+var csvColumns = []string{"name", "org_id", "paths"}
+
+// Streams folders out of CSV one row at a time, so large inventories never sit in memory
+type CSVReader struct {
+	r *csv.Reader
+	// index of each of csvColumns in a row
+	columns map[string]int
+}
+
+// Reads and validates the header row of r
+func NewCSVReader(r io.Reader) (*CSVReader, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("readCSV: missing header row, expected %v", csvColumns)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("readCSV: %w", err)
+	}
+
+	// spreadsheet exports often start with a UTF-8 byte order mark
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		if _, dup := columns[column]; dup {
+			return nil, fmt.Errorf("readCSV: line 1: duplicate column '%s'", column)
+		}
+		columns[column] = i
+	}
+	for _, column := range csvColumns {
+		if _, found := columns[column]; !found {
+			return nil, fmt.Errorf("readCSV: line 1: missing column '%s', expected %v", column, csvColumns)
+		}
+	}
+	if len(columns) != len(csvColumns) {
+		return nil, fmt.Errorf("readCSV: line 1: unexpected columns %v, expected %v", header, csvColumns)
+	}
+
+	return &CSVReader{r: cr, columns: columns}, nil
+}
+
+// Returns the next folder, or io.EOF once every row has been read
+func (r *CSVReader) Read() (Folder, error) {
+	record, err := r.r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return Folder{}, io.EOF
+		}
+		return Folder{}, fmt.Errorf("readCSV: %w", err)
+	}
+	line, _ := r.r.FieldPos(0)
+
+	f := Folder{
+		Name:  record[r.columns["name"]],
+		Paths: record[r.columns["paths"]],
+	}
+	orgID := record[r.columns["org_id"]]
+	if f.OrgId, err = uuid.FromString(orgID); err != nil || f.OrgId == uuid.Nil {
+		return Folder{}, fmt.Errorf("readCSV: line %d: invalid org_id '%s'", line, orgID)
+	}
+	if err := validateName(f.Name); err != nil {
+		return Folder{}, fmt.Errorf("readCSV: line %d: %w", line, err)
+	}
+	if labels := ltree.Labels(f.Paths); len(labels) == 0 || labels[len(labels)-1] != f.Name {
+		return Folder{}, fmt.Errorf("readCSV: line %d: paths '%s' does not end with folder name '%s'", line, f.Paths, f.Name)
+	}
+	return f, nil
+}
+
+// Reads every folder from CSV
+func ReadFoldersCSV(r io.Reader) ([]Folder, error) {
+	cr, err := NewCSVReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	folders := []Folder{}
+	for {
+		f, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return folders, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		folders = append(folders, f)
+	}
+}
+
+// Streams folders as CSV rows, call Flush once done
+type CSVWriter struct {
+	w *csv.Writer
+}
+
+// Writes the header row to w
+func NewCSVWriter(w io.Writer) (*CSVWriter, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvColumns); err != nil {
+		return nil, fmt.Errorf("writeCSV: %w", err)
+	}
+	return &CSVWriter{w: cw}, nil
+}
+
+func (w *CSVWriter) Write(f Folder) error {
+	if err := w.w.Write([]string{f.Name, f.OrgId.String(), f.Paths}); err != nil {
+		return fmt.Errorf("writeCSV: %w", err)
+	}
+	return nil
+}
+
+// Flushes buffered rows to the underlying writer
+func (w *CSVWriter) Flush() error {
+	w.w.Flush()
+	if err := w.w.Error(); err != nil {
+		return fmt.Errorf("writeCSV: %w", err)
+	}
+	return nil
+}
+
+// Writes a header row followed by one row per folder
+func WriteFoldersCSV(w io.Writer, folders []Folder) error {
+	cw, err := NewCSVWriter(w)
+	if err != nil {
+		return err
+	}
+	for _, f := range folders {
+		if err := cw.Write(f); err != nil {
+			return err
+		}
+	}
+	return cw.Flush()
+}
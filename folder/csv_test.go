@@ -0,0 +1,162 @@
+package folder_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_ReadFoldersCSV(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	org := orgId.String()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []folder.Folder
+		wantErr string
+	}{
+		// Functionalities
+		{
+			name:  "header in the documented order",
+			input: "name,org_id,paths\nalpha," + org + ",alpha\nbravo," + org + ",alpha.bravo\n",
+			want: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId},
+			},
+		},
+		{
+			name:  "columns in another order",
+			input: "paths, name, org_id\nalpha.bravo, bravo, " + org + "\n",
+			want: []folder.Folder{
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId},
+			},
+		},
+		{
+			name:  "header with a byte order mark",
+			input: "\ufeffname,org_id,paths\nalpha," + org + ",alpha\n",
+			want: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId},
+			},
+		},
+		{
+			name:  "header only",
+			input: "name,org_id,paths\n",
+			want:  []folder.Folder{},
+		},
+
+		// error checking
+		{
+			name:    "empty input",
+			input:   "",
+			wantErr: "missing header row",
+		},
+		{
+			name:    "missing column",
+			input:   "name,paths\nalpha,alpha\n",
+			wantErr: "line 1: missing column 'org_id'",
+		},
+		{
+			name:    "extra column",
+			input:   "name,org_id,paths,owner\nalpha," + org + ",alpha,bob\n",
+			wantErr: "line 1: unexpected columns",
+		},
+		{
+			name:    "duplicate column",
+			input:   "name,name,org_id,paths\n",
+			wantErr: "line 1: duplicate column 'name'",
+		},
+		{
+			name:    "invalid org_id",
+			input:   "name,org_id,paths\nalpha," + org + ",alpha\nbravo,not-a-uuid,alpha.bravo\n",
+			wantErr: "line 3: invalid org_id 'not-a-uuid'",
+		},
+		{
+			name:    "name containing a dot",
+			input:   "name,org_id,paths\nalpha.bravo," + org + ",alpha.bravo\n",
+			wantErr: "line 2: folder name 'alpha.bravo' cannot contain '.'",
+		},
+		{
+			name:    "paths not ending with the name",
+			input:   "name,org_id,paths\nalpha," + org + ",alpha\nbravo," + org + ",alpha.charlie\n",
+			wantErr: "line 3: paths 'alpha.charlie' does not end with folder name 'bravo'",
+		},
+		{
+			name:    "wrong number of fields",
+			input:   "name,org_id,paths\nalpha," + org + "\n",
+			wantErr: "line 2",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := folder.ReadFoldersCSV(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ReadFoldersCSV() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadFoldersCSV() received unexpected error: %v", err)
+			}
+			if !compareFolders(got, tt.want) {
+				t.Errorf("ReadFoldersCSV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_folder_CSVReader_Streams(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	input := "name,org_id,paths\nalpha," + orgId.String() + ",alpha\nbravo,oops,alpha.bravo\n"
+
+	r, err := folder.NewCSVReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("NewCSVReader() received unexpected error: %v", err)
+	}
+	// rows before a bad row are still returned
+	if f, err := r.Read(); err != nil || f.Name != "alpha" {
+		t.Fatalf("Read() = %v, %v, want alpha", f, err)
+	}
+	if _, err := r.Read(); err == nil || errors.Is(err, io.EOF) {
+		t.Fatalf("Read() of a bad row error = %v, want a row error", err)
+	}
+	if _, err := r.Read(); !errors.Is(err, io.EOF) {
+		t.Errorf("Read() after the last row error = %v, want io.EOF", err)
+	}
+}
+
+func Test_folder_WriteFoldersCSV(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	folders := []folder.Folder{
+		{Name: "alpha", Paths: "alpha", OrgId: orgId},
+		{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId},
+	}
+
+	var buf bytes.Buffer
+	if err := folder.WriteFoldersCSV(&buf, folders); err != nil {
+		t.Fatalf("WriteFoldersCSV() received unexpected error: %v", err)
+	}
+	want := "name,org_id,paths\n" +
+		"alpha," + orgId.String() + ",alpha\n" +
+		"bravo," + orgId.String() + ",alpha.bravo\n"
+	if buf.String() != want {
+		t.Errorf("WriteFoldersCSV() wrote\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	got, err := folder.ReadFoldersCSV(&buf)
+	if err != nil {
+		t.Fatalf("ReadFoldersCSV() received unexpected error: %v", err)
+	}
+	if !compareFolders(got, folders) {
+		t.Errorf("ReadFoldersCSV(WriteFoldersCSV()) = %v, want %v", got, folders)
+	}
+}
@@ -0,0 +1,107 @@
+package folder
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+)
+
+// Controls how DeleteFolder treats folders that have children
+type DeleteOptions struct {
+	// Recursive deletes the folder along with its whole subtree,
+	// otherwise deleting a folder with children is an error
+	Recursive bool
+}
+
+// Deletes the folder called name within orgID and returns every folder that was removed.
+func (d *driver) DeleteFolder(orgID uuid.UUID, name string, opts DeleteOptions) ([]Folder, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if orgID == uuid.Nil {
+		return nil, invalidOrgIDError("deleteFolder", orgID)
+	}
+
+	target, err := d.getFolderInOrg(orgID, name)
+	if err != nil {
+		return nil, fmt.Errorf("deleteFolder: %w", err)
+	}
+
+	if len(target.Children) > 0 && !opts.Recursive {
+		return nil, &FolderError{
+			Err:   ErrHasChildren,
+			Name:  name,
+			OrgID: orgID,
+			Path:  target.Paths,
+			msg:   fmt.Sprintf("deleteFolder: folder '%s' has %d children, use a recursive delete", name, len(target.Children)),
+		}
+	}
+
+	// detach subtree from its parent
+	if target.Parent != nil {
+		if err := d.removeChild(target.Parent, target); err != nil {
+			return nil, fmt.Errorf("deleteFolder: error removing folder from its parent: %w", err)
+		}
+	}
+
+	// collect target and all descendents, parents are collected before children
+	removed := []*Folder{}
+	var collect func(*Folder)
+	collect = func(currFolder *Folder) {
+		removed = append(removed, currFolder)
+		for _, child := range currFolder.Children {
+			collect(child)
+		}
+	}
+	collect(target)
+
+	removedSet := make(map[*Folder]bool, len(removed))
+	for _, folder := range removed {
+		removedSet[folder] = true
+		delete(d.pathIndex, pathKey{folder.OrgId, folder.Paths})
+		d.nameIndex[folder.Name] = removeFolder(d.nameIndex[folder.Name], folder)
+		if len(d.nameIndex[folder.Name]) == 0 {
+			delete(d.nameIndex, folder.Name)
+		}
+	}
+	d.orgIdIndex[orgID] = filterFolders(d.orgIdIndex[orgID], removedSet)
+	if len(d.orgIdIndex[orgID]) == 0 {
+		delete(d.orgIdIndex, orgID)
+	}
+	d.folders = filterFolders(d.folders, removedSet)
+
+	res := make([]Folder, 0, len(removed))
+	for _, folder := range removed {
+		res = append(res, *folder)
+	}
+
+	// fully detach the removed subtree root so it no longer references the live tree
+	target.Parent = nil
+
+	return res, nil
+}
+
+// removes a single folder pointer from a slice of folders
+func removeFolder(folders []*Folder, target *Folder) []*Folder {
+	for i, f := range folders {
+		if f == target {
+			return append(folders[:i], folders[i+1:]...)
+		}
+	}
+	return folders
+}
+
+// returns folders without any folder contained in removed, preserving order
+func filterFolders(folders []*Folder, removed map[*Folder]bool) []*Folder {
+	kept := folders[:0]
+	for _, f := range folders {
+		if !removed[f] {
+			kept = append(kept, f)
+		}
+	}
+	// clear the tail so removed folders can be garbage collected
+	for i := len(kept); i < len(folders); i++ {
+		folders[i] = nil
+	}
+	return kept
+}
@@ -0,0 +1,139 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_DeleteFolder(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name          string
+		orgID         uuid.UUID
+		folderName    string
+		opts          folder.DeleteOptions
+		wantRemoved   []folder.Folder
+		wantRemaining []folder.Folder
+		wantRunError  bool
+	}{
+		// Functionalities
+		{
+			name:        "delete leaf folder",
+			orgID:       orgId1,
+			folderName:  "charlie",
+			wantRemoved: []folder.Folder{{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1}},
+			wantRemaining: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+			},
+		},
+		{
+			name:       "recursively delete folder with children",
+			orgID:      orgId1,
+			folderName: "bravo",
+			opts:       folder.DeleteOptions{Recursive: true},
+			wantRemoved: []folder.Folder{
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+			},
+			wantRemaining: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+			},
+		},
+		{
+			name:       "recursively delete root folder",
+			orgID:      orgId1,
+			folderName: "alpha",
+			opts:       folder.DeleteOptions{Recursive: true},
+			wantRemoved: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+			},
+			wantRemaining: []folder.Folder{},
+		},
+		{
+			name:        "delete folder whose name also exists in a different org",
+			orgID:       orgId2,
+			folderName:  "delta",
+			wantRemoved: []folder.Folder{{Name: "delta", Paths: "foxtrot.delta", OrgId: orgId2}},
+			wantRemaining: []folder.Folder{
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+			},
+		},
+
+		// edge cases and error checking
+		{
+			name:         "error deleting folder with children non-recursively",
+			orgID:        orgId1,
+			folderName:   "bravo",
+			wantRunError: true,
+		},
+		{
+			name:         "error deleting non-existent folder",
+			orgID:        orgId1,
+			folderName:   "invalid_folder",
+			wantRunError: true,
+		},
+		{
+			name:         "error deleting folder in a different org",
+			orgID:        orgId1,
+			folderName:   "foxtrot",
+			wantRunError: true,
+		},
+		{
+			name:         "error deleting with invalid orgID",
+			orgID:        uuid.Nil,
+			folderName:   "alpha",
+			wantRunError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := folder.NewDriver([]folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+				{Name: "delta", Paths: "foxtrot.delta", OrgId: orgId2},
+			})
+			if err != nil {
+				t.Fatalf("driver failed to initialise - %v", err)
+			}
+
+			got, err := driver.DeleteFolder(tt.orgID, tt.folderName, tt.opts)
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("DeleteFolder() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if tt.wantRunError {
+				return
+			}
+
+			if !compareFolders(got, tt.wantRemoved) {
+				t.Errorf("DeleteFolder() = %v, want %v", got, tt.wantRemoved)
+			}
+
+			remaining := driver.GetFoldersByOrgID(tt.orgID)
+			if !compareFolders(remaining, tt.wantRemaining) {
+				t.Errorf("GetFoldersByOrgID() after delete = %v, want %v", remaining, tt.wantRemaining)
+			}
+
+			// removed names must be free to use again
+			if _, err := driver.CreateFolder(tt.orgID, "", tt.folderName); err != nil {
+				t.Errorf("CreateFolder() after delete received unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package folder
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/georgechieng-sc/interns-2022/ltree"
+	"github.com/gofrs/uuid"
+)
+
+// Creates one directory under root for every folder in orgID, "a.b.c" becomes root/a/b/c.
+// Existing directories are left in place so an export can be re-run over an earlier one.
+func ExportToDir(driver IDriver, orgID uuid.UUID, root string) error {
+	if orgID == uuid.Nil {
+		return invalidOrgIDError("exportToDir", orgID)
+	}
+
+	folders := driver.GetFoldersByOrgID(orgID)
+	// check every path before creating anything, a label holding a
+	// separator would be exported as nested directories
+	for _, folder := range folders {
+		for _, label := range ltree.Labels(folder.Paths) {
+			if label == "" || strings.ContainsAny(label, `/\`) {
+				return &FolderError{
+					Err:   ErrInvalidName,
+					Name:  folder.Name,
+					OrgID: orgID,
+					Path:  folder.Paths,
+					msg:   fmt.Sprintf("exportToDir: path '%s' cannot be used as a directory name", folder.Paths),
+				}
+			}
+		}
+	}
+
+	for _, folder := range folders {
+		dir := filepath.Join(root, filepath.FromSlash(strings.ReplaceAll(folder.Paths, ".", "/")))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("exportToDir: %w", err)
+		}
+	}
+	return nil
+}
+
+// Walks every directory in fsys and returns it as a folder of orgID, regular files are ignored.
+// Directory names containing '.' and names used twice are all reported together, as
+// FolderErrors that match ErrInvalidName and ErrDuplicateName.
+func ImportFromDir(fsys fs.FS, orgID uuid.UUID) ([]Folder, error) {
+	if orgID == uuid.Nil {
+		return nil, invalidOrgIDError("importFromDir", orgID)
+	}
+
+	folders := []Folder{}
+	// directory holding each name seen so far, names are unique per org
+	seen := make(map[string]string)
+	var errs []error
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() || p == "." {
+			return nil
+		}
+
+		name := d.Name()
+		if strings.Contains(name, ".") {
+			errs = append(errs, &FolderError{
+				Err:   ErrInvalidName,
+				Name:  name,
+				OrgID: orgID,
+				msg:   fmt.Sprintf("importFromDir: directory '%s': folder name '%s' cannot contain '.'", p, name),
+			})
+			// the paths of anything below would not parse, so the subtree is not imported
+			return fs.SkipDir
+		}
+		if prev, dup := seen[name]; dup {
+			errs = append(errs, &FolderError{
+				Err:   ErrDuplicateName,
+				Name:  name,
+				OrgID: orgID,
+				msg:   fmt.Sprintf("importFromDir: directory '%s': folder name '%s' is already used by '%s'", p, name, prev),
+			})
+			return nil
+		}
+		seen[name] = p
+
+		folders = append(folders, Folder{
+			Name:  name,
+			OrgId: orgID,
+			Paths: ltree.Join(strings.Split(path.Clean(p), "/")...),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("importFromDir: %w", err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return folders, nil
+}
@@ -0,0 +1,207 @@
+package folder_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_ImportFromDir(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name     string
+		fsys     fstest.MapFS
+		orgID    uuid.UUID
+		want     []folder.Folder
+		wantErrs []error
+	}{
+		// Functionalities
+		{
+			name: "nested directories",
+			fsys: fstest.MapFS{
+				"alpha/bravo/charlie": &fstest.MapFile{Mode: os.ModeDir},
+				"alpha/delta":         &fstest.MapFile{Mode: os.ModeDir},
+				"echo":                &fstest.MapFile{Mode: os.ModeDir},
+			},
+			orgID: orgId,
+			want: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId},
+				{Name: "echo", Paths: "echo", OrgId: orgId},
+			},
+		},
+		{
+			name: "regular files are ignored",
+			fsys: fstest.MapFS{
+				"alpha/notes_txt":     &fstest.MapFile{Data: []byte("hello")},
+				"alpha/bravo/readme":  &fstest.MapFile{Data: []byte("hi")},
+				"alpha/bravo/charlie": &fstest.MapFile{Mode: os.ModeDir},
+			},
+			orgID: orgId,
+			want: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId},
+			},
+		},
+		{
+			name:  "empty directory",
+			fsys:  fstest.MapFS{},
+			orgID: orgId,
+			want:  []folder.Folder{},
+		},
+
+		// error checking
+		{
+			name: "name containing a dot",
+			fsys: fstest.MapFS{
+				"alpha/bravo.v2/charlie": &fstest.MapFile{Mode: os.ModeDir},
+			},
+			orgID:    orgId,
+			wantErrs: []error{folder.ErrInvalidName},
+		},
+		{
+			name: "duplicate name",
+			fsys: fstest.MapFS{
+				"alpha/bravo": &fstest.MapFile{Mode: os.ModeDir},
+				"delta/bravo": &fstest.MapFile{Mode: os.ModeDir},
+			},
+			orgID:    orgId,
+			wantErrs: []error{folder.ErrDuplicateName},
+		},
+		{
+			name: "every problem is reported",
+			fsys: fstest.MapFS{
+				"alpha/bravo":   &fstest.MapFile{Mode: os.ModeDir},
+				"delta/bravo":   &fstest.MapFile{Mode: os.ModeDir},
+				"echo/.hidden":  &fstest.MapFile{Mode: os.ModeDir},
+				"foxtrot/alpha": &fstest.MapFile{Mode: os.ModeDir},
+			},
+			orgID:    orgId,
+			wantErrs: []error{folder.ErrInvalidName, folder.ErrDuplicateName},
+		},
+		{
+			name:     "invalid orgID",
+			fsys:     fstest.MapFS{"alpha": &fstest.MapFile{Mode: os.ModeDir}},
+			orgID:    uuid.Nil,
+			wantErrs: []error{folder.ErrInvalidOrgID},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := folder.ImportFromDir(tt.fsys, tt.orgID)
+			if len(tt.wantErrs) > 0 {
+				for _, want := range tt.wantErrs {
+					if !errors.Is(err, want) {
+						t.Errorf("ImportFromDir() error = %v, want errors.Is %v", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportFromDir() received unexpected error: %v", err)
+			}
+			if !compareFolders(got, tt.want) {
+				t.Errorf("ImportFromDir() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_folder_ImportFromDir_ReportsEachDuplicate(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	fsys := fstest.MapFS{
+		"alpha/bravo":   &fstest.MapFile{Mode: os.ModeDir},
+		"delta/bravo":   &fstest.MapFile{Mode: os.ModeDir},
+		"echo/bravo":    &fstest.MapFile{Mode: os.ModeDir},
+		"foxtrot/a.b/c": &fstest.MapFile{Mode: os.ModeDir},
+	}
+
+	_, err := folder.ImportFromDir(fsys, orgId)
+	if err == nil {
+		t.Fatal("ImportFromDir() expected an error but got none")
+	}
+	msg := err.Error()
+	for _, want := range []string{"'delta/bravo'", "'echo/bravo'", "'foxtrot/a.b'"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("ImportFromDir() error does not mention %s:\n%s", want, msg)
+		}
+	}
+	var folderErr *folder.FolderError
+	if !errors.As(err, &folderErr) || folderErr.OrgID != orgId {
+		t.Errorf("ImportFromDir() error = %v, want a FolderError for org %s", err, orgId)
+	}
+}
+
+func Test_folder_ExportToDir(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+	folders := []folder.Folder{
+		{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+		{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+		{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+		{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+		{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+	}
+	driver, err := folder.NewDriver(folders)
+	if err != nil {
+		t.Fatalf("driver failed to initialise - %v", err)
+	}
+
+	t.Run("round trip through a directory", func(t *testing.T) {
+		root := t.TempDir()
+		if err := folder.ExportToDir(driver, orgId1, root); err != nil {
+			t.Fatalf("ExportToDir() received unexpected error: %v", err)
+		}
+		// exporting again over an existing tree is allowed
+		if err := folder.ExportToDir(driver, orgId1, root); err != nil {
+			t.Fatalf("second ExportToDir() received unexpected error: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(root, "foxtrot")); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("folder of another org was exported, Stat() error = %v", err)
+		}
+
+		got, err := folder.ImportFromDir(os.DirFS(root), orgId1)
+		if err != nil {
+			t.Fatalf("ImportFromDir() received unexpected error: %v", err)
+		}
+		if !compareFolders(got, folders[:4]) {
+			t.Errorf("ImportFromDir(ExportToDir()) = %v, want %v", got, folders[:4])
+		}
+	})
+
+	t.Run("name containing a separator", func(t *testing.T) {
+		driver, err := folder.NewDriver([]folder.Folder{
+			{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+			{Name: "br/avo", Paths: "alpha.br/avo", OrgId: orgId1},
+		})
+		if err != nil {
+			t.Fatalf("driver failed to initialise - %v", err)
+		}
+		root := t.TempDir()
+		if err := folder.ExportToDir(driver, orgId1, root); !errors.Is(err, folder.ErrInvalidName) {
+			t.Errorf("ExportToDir() error = %v, want %v", err, folder.ErrInvalidName)
+		}
+		if entries, _ := os.ReadDir(root); len(entries) != 0 {
+			t.Errorf("ExportToDir() created %d entries, want none", len(entries))
+		}
+	})
+
+	t.Run("invalid orgID", func(t *testing.T) {
+		if err := folder.ExportToDir(driver, uuid.Nil, t.TempDir()); !errors.Is(err, folder.ErrInvalidOrgID) {
+			t.Errorf("ExportToDir() error = %v, want %v", err, folder.ErrInvalidOrgID)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package folder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+)
+
+// Sentinel errors returned by the driver, match them with errors.Is.
+// Use errors.As with *FolderError to get the folder an error is about.
+var (
+	ErrFolderNotFound = errors.New("folder not found")
+	ErrWrongOrg       = errors.New("folder does not exist in org")
+	ErrCycle          = errors.New("folder cycle")
+	ErrDuplicateName  = errors.New("duplicate folder name")
+	ErrInvalidOrgID   = errors.New("invalid org id")
+	ErrAmbiguousName  = errors.New("ambiguous folder name")
+	ErrInvalidName    = errors.New("invalid folder name")
+	ErrHasChildren    = errors.New("folder has children")
+	ErrInvalidPattern = errors.New("invalid lquery pattern")
+)
+
+// Error about a specific folder, unwraps to one of the sentinel errors
+type FolderError struct {
+	// Err is the sentinel error describing what went wrong
+	Err   error
+	Name  string
+	OrgID uuid.UUID
+	Path  string
+	msg   string
+}
+
+func (e *FolderError) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	return e.Err.Error()
+}
+
+func (e *FolderError) Unwrap() error {
+	return e.Err
+}
+
+func invalidOrgIDError(op string, orgID uuid.UUID) error {
+	return &FolderError{
+		Err:   ErrInvalidOrgID,
+		OrgID: orgID,
+		msg:   fmt.Sprintf("%s: invalid OrgID - '%s'", op, orgID),
+	}
+}
+
+func duplicateNameError(op string, name string, orgID uuid.UUID) error {
+	return &FolderError{
+		Err:   ErrDuplicateName,
+		Name:  name,
+		OrgID: orgID,
+		msg:   fmt.Sprintf("%s: duplicate folder name '%s' in OrgId '%s'", op, name, orgID),
+	}
+}
@@ -0,0 +1,228 @@
+package folder_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_Errors(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name     string
+		run      func(d folder.IDriver) error
+		wantErr  error
+		wantName string
+		wantOrg  uuid.UUID
+		wantPath string
+	}{
+		{
+			name: "child folders of a folder that does not exist",
+			run: func(d folder.IDriver) error {
+				_, err := d.GetAllChildFolders(orgId1, "invalid_folder")
+				return err
+			},
+			wantErr:  folder.ErrFolderNotFound,
+			wantName: "invalid_folder",
+			wantOrg:  orgId1,
+		},
+		{
+			name: "child folders of a folder in a different org",
+			run: func(d folder.IDriver) error {
+				_, err := d.GetAllChildFolders(orgId1, "foxtrot")
+				return err
+			},
+			wantErr:  folder.ErrWrongOrg,
+			wantName: "foxtrot",
+			wantOrg:  orgId1,
+		},
+		{
+			name: "invalid org id",
+			run: func(d folder.IDriver) error {
+				_, err := d.GetAllChildFolders(uuid.Nil, "alpha")
+				return err
+			},
+			wantErr: folder.ErrInvalidOrgID,
+		},
+		{
+			name: "folder at a path that does not exist",
+			run: func(d folder.IDriver) error {
+				_, err := d.GetFolderByPath(orgId1, "alpha.invalid_folder")
+				return err
+			},
+			wantErr:  folder.ErrFolderNotFound,
+			wantOrg:  orgId1,
+			wantPath: "alpha.invalid_folder",
+		},
+		{
+			name: "move a folder into its own child",
+			run: func(d folder.IDriver) error {
+				_, err := d.MoveFolder("bravo", "charlie")
+				return err
+			},
+			wantErr:  folder.ErrCycle,
+			wantName: "bravo",
+			wantOrg:  orgId1,
+			wantPath: "alpha.bravo.charlie",
+		},
+		{
+			name: "move a folder to itself",
+			run: func(d folder.IDriver) error {
+				_, err := d.MoveFolderInOrg(orgId1, "bravo", "bravo")
+				return err
+			},
+			wantErr:  folder.ErrCycle,
+			wantName: "bravo",
+		},
+		{
+			name: "move folders across orgs",
+			run: func(d folder.IDriver) error {
+				_, err := d.MoveFolder("bravo", "foxtrot")
+				return err
+			},
+			wantErr:  folder.ErrWrongOrg,
+			wantName: "bravo",
+		},
+		{
+			name: "move folders whose names exist together in more than one org",
+			run: func(d folder.IDriver) error {
+				_, err := d.MoveFolder("delta", "echo")
+				return err
+			},
+			wantErr:  folder.ErrAmbiguousName,
+			wantName: "delta",
+		},
+		{
+			name: "move a source folder that does not exist",
+			run: func(d folder.IDriver) error {
+				_, err := d.MoveFolder("invalid_folder", "alpha")
+				return err
+			},
+			wantErr:  folder.ErrFolderNotFound,
+			wantName: "invalid_folder",
+		},
+		{
+			name: "create a folder with a duplicate name",
+			run: func(d folder.IDriver) error {
+				_, err := d.CreateFolder(orgId1, "", "bravo")
+				return err
+			},
+			wantErr:  folder.ErrDuplicateName,
+			wantName: "bravo",
+			wantOrg:  orgId1,
+		},
+		{
+			name: "rename a folder to a name containing '.'",
+			run: func(d folder.IDriver) error {
+				_, err := d.RenameFolder(orgId1, "bravo", "hotel.india")
+				return err
+			},
+			wantErr:  folder.ErrInvalidName,
+			wantName: "hotel.india",
+		},
+		{
+			name: "delete a folder with children non-recursively",
+			run: func(d folder.IDriver) error {
+				_, err := d.DeleteFolder(orgId1, "bravo", folder.DeleteOptions{})
+				return err
+			},
+			wantErr:  folder.ErrHasChildren,
+			wantName: "bravo",
+			wantOrg:  orgId1,
+			wantPath: "alpha.bravo",
+		},
+		{
+			name: "move across orgs onto an existing name",
+			run: func(d folder.IDriver) error {
+				_, err := d.MoveFolderAcrossOrgs(orgId1, "delta", orgId2, "", folder.ConflictFail)
+				return err
+			},
+			wantErr:  folder.ErrDuplicateName,
+			wantName: "delta",
+			wantOrg:  orgId2,
+			wantPath: "foxtrot.delta",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := folder.NewDriver([]folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+				{Name: "echo", Paths: "echo", OrgId: orgId1},
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+				{Name: "delta", Paths: "foxtrot.delta", OrgId: orgId2},
+				{Name: "echo", Paths: "foxtrot.echo", OrgId: orgId2},
+			})
+			if err != nil {
+				t.Fatalf("driver failed to initialise - %v", err)
+			}
+
+			err = tt.run(driver)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want errors.Is %v", err, tt.wantErr)
+			}
+
+			var folderErr *folder.FolderError
+			if !errors.As(err, &folderErr) {
+				t.Fatalf("error = %v, want a *folder.FolderError", err)
+			}
+			if folderErr.Name != tt.wantName || folderErr.OrgID != tt.wantOrg || folderErr.Path != tt.wantPath {
+				t.Errorf("FolderError{Name: %q, OrgID: %s, Path: %q}, want {Name: %q, OrgID: %s, Path: %q}",
+					folderErr.Name, folderErr.OrgID, folderErr.Path, tt.wantName, tt.wantOrg, tt.wantPath)
+			}
+		})
+	}
+}
+
+func Test_folder_NewDriver_Errors(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name    string
+		folders []folder.Folder
+		wantErr error
+	}{
+		{
+			name: "duplicate name in the same org",
+			folders: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId},
+				{Name: "alpha", Paths: "bravo.alpha", OrgId: orgId},
+			},
+			wantErr: folder.ErrDuplicateName,
+		},
+		{
+			name: "cyclic path",
+			folders: []folder.Folder{
+				{Name: "alpha", Paths: "alpha.bravo.alpha", OrgId: orgId},
+			},
+			wantErr: folder.ErrCycle,
+		},
+		{
+			name: "missing parent",
+			folders: []folder.Folder{
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId},
+			},
+			wantErr: folder.ErrFolderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := folder.NewDriver(tt.folders)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewDriver() error = %v, want errors.Is %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,9 +2,12 @@ package folder
 
 import (
 	"fmt"
+	"io/fs"
+	"log/slog"
 	"strings"
 	"sync"
 
+	"github.com/georgechieng-sc/interns-2022/ltree"
 	"github.com/gofrs/uuid"
 )
 
@@ -15,83 +18,93 @@ type IDriver interface {
 	// Component 1
 	GetAllChildFolders(orgID uuid.UUID, name string) ([]Folder, error)
 
+	// GetChildFolders returns descendents of a folder down to maxDepth levels, 0 is unlimited.
+	GetChildFolders(orgID uuid.UUID, name string, maxDepth int, order TraversalOrder) ([]Folder, error)
+
 	// Component 2
 	MoveFolder(name string, dst string) ([]Folder, error)
+
+	// MoveFolderInOrg moves a folder under dst within an explicit org.
+	MoveFolderInOrg(orgID uuid.UUID, name string, dst string) ([]Folder, error)
+
+	// GetFolderByPath returns the folder at an exact ltree path within an org.
+	GetFolderByPath(orgID uuid.UUID, path string) (Folder, error)
+
+	// MatchPaths returns the folders whose paths match a PostgreSQL lquery pattern.
+	MatchPaths(orgID uuid.UUID, pattern string) ([]Folder, error)
+
+	// IsAncestor reports whether folder a is folder b or one of its ancestors, the ltree @> operator.
+	IsAncestor(orgID uuid.UUID, a string, b string) (bool, error)
+
+	// LowestCommonAncestor returns the deepest folder that is an ancestor of, or equal to, every named folder.
+	LowestCommonAncestor(orgID uuid.UUID, names ...string) (Folder, error)
+
+	// GetAncestors returns the chain of folders from the root down to and including the named folder.
+	GetAncestors(orgID uuid.UUID, name string) ([]Folder, error)
+
+	// BuildTree returns every root folder of an org as a nested tree.
+	BuildTree(orgID uuid.UUID) ([]TreeNode, error)
+
+	// SubTree returns a folder and its descendents as a nested tree.
+	SubTree(orgID uuid.UUID, name string) (TreeNode, error)
+
+	// FS returns the folders of an org as a read-only file system of directories.
+	FS(orgID uuid.UUID) fs.FS
+
+	// CreateFolder adds a new folder under parentName, or as a root folder if parentName is empty.
+	CreateFolder(orgID uuid.UUID, parentName string, name string) (Folder, error)
+
+	// DeleteFolder removes a folder, and its subtree when opts.Recursive is set.
+	DeleteFolder(orgID uuid.UUID, name string, opts DeleteOptions) ([]Folder, error)
+
+	// RenameFolder renames a folder and rewrites the paths of all of its descendents.
+	RenameFolder(orgID uuid.UUID, oldName string, newName string) ([]Folder, error)
+
+	// CopyFolder deep copies a subtree under dst, naming the copies through namingFn.
+	CopyFolder(orgID uuid.UUID, src string, dst string, namingFn NamingFunc) ([]Folder, error)
+
+	// MoveFolderAcrossOrgs moves a subtree into another org, resolving name collisions through policy.
+	MoveFolderAcrossOrgs(srcOrg uuid.UUID, name string, dstOrg uuid.UUID, dst string, policy ConflictPolicy) ([]Folder, error)
+
+	// CopyFolderAcrossOrgs copies a subtree into another org, resolving name collisions through policy.
+	CopyFolderAcrossOrgs(srcOrg uuid.UUID, name string, dstOrg uuid.UUID, dst string, policy ConflictPolicy) ([]Folder, error)
 }
 
 // Manages folder hierarchy
 type driver struct {
 	// Stores slice of all folders
-	folders   []*Folder
-	pathIndex map[string]*Folder
+	folders []*Folder
+	// paths are only unique within an org, so pathIndex is keyed by (orgId, path)
+	pathIndex map[pathKey]*Folder
 	// nameIndex and orgId index must account for folders with duplcate names and orgIds
 	nameIndex  map[string][]*Folder
 	orgIdIndex map[uuid.UUID][]*Folder
 	mu         sync.RWMutex
+	logger     *slog.Logger
+}
+
+// Key of driver.pathIndex, identical paths may exist in different orgs
+type pathKey struct {
+	orgId uuid.UUID
+	path  string
 }
 
 // Initialises FolderDriver, populating parent child
 // TODO: Implement cycle detection
-func NewDriver(folders []Folder) (IDriver, error) {
-	folderDriver := &driver{
-		folders:    []*Folder{},
-		pathIndex:  make(map[string]*Folder),
-		nameIndex:  make(map[string][]*Folder),
-		orgIdIndex: make(map[uuid.UUID][]*Folder),
-		// mutex lock does not require explicit initialisation
-	}
-
+func NewDriver(folders []Folder, opts ...Option) (IDriver, error) {
+	builder := NewDriverBuilder(opts...)
 	// populate folders and maps of driver, folders is a slice of Folder, NOT *Folder
 	for _, folder := range folders {
-		f := folder
-
-		// Check for duplicate folder names within the same OrgId
-		for _, existingFolder := range folderDriver.nameIndex[f.Name] {
-			if existingFolder.OrgId == f.OrgId {
-				return nil, fmt.Errorf("newDriver: duplicate folder name '%s' in OrgId '%s'", f.Name, f.OrgId)
-			}
-		}
-
-		// Check for cycles
-		if hasRepeats(f.Paths) {
-			return nil, fmt.Errorf("newDriver: cannot instantiate path %s has it will create a cycle", folder.Paths)
+		if err := builder.Add(folder); err != nil {
+			return nil, err
 		}
-
-		folderDriver.folders = append(folderDriver.folders, &f)
-		folderDriver.pathIndex[f.Paths] = &f
-		folderDriver.nameIndex[f.Name] = append(folderDriver.nameIndex[f.Name], &f)
-		folderDriver.orgIdIndex[f.OrgId] = append(folderDriver.orgIdIndex[f.OrgId], &f)
-	}
-
-	// folderDriver.folders stores a slice of *Folder
-	for _, folder := range folderDriver.folders {
-		// populate child and parent
-		// obtain parent and set children
-		parentPath := getParentPath(folder.Paths)
-		// root path
-		if parentPath == "" {
-			continue
-		}
-		parentFolder, found := folderDriver.pathIndex[parentPath]
-		if !found {
-			return nil, fmt.Errorf("newDriver: Parent oath '%s' not found for folder '%s'", parentPath, folder.Name)
-		}
-
-		// establish parent child
-		parentFolder.Children = append(parentFolder.Children, folder)
-		folder.Parent = parentFolder
 	}
-
-	return folderDriver, nil
+	return builder.Build()
 }
 
 // get the substring of childPath up until the last dot
 func getParentPath(childPath string) string {
-	lastDot := strings.LastIndex(childPath, ".")
-	if lastDot == -1 {
-		return ""
-	}
-	return childPath[:lastDot]
+	return ltree.Parent(childPath)
 }
 
 // Checks if there exists repeated foldeName in path string
@@ -107,6 +120,13 @@ func hasRepeats(s string) bool {
 	return false
 }
 
+// Returns a copy of every folder under the read lock
+func (d *driver) snapshot() []Folder {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.getAllFolders()
+}
+
 // Returns referenced folders
 func (d *driver) getAllFolders() []Folder {
 	var allFolders []Folder
@@ -115,3 +135,38 @@ func (d *driver) getAllFolders() []Folder {
 	}
 	return allFolders
 }
+
+// Checks that a folder name can be used as a single ltree label
+func validateName(name string) error {
+	if name == "" {
+		return &FolderError{Err: ErrInvalidName, msg: "folder name cannot be empty"}
+	}
+	if strings.Contains(name, ".") {
+		return &FolderError{Err: ErrInvalidName, Name: name, msg: fmt.Sprintf("folder name '%s' cannot contain '.'", name)}
+	}
+	return nil
+}
+
+// Returns the folder with the given name in orgId, names are unique per org
+func (d *driver) getFolderInOrg(orgId uuid.UUID, name string) (*Folder, error) {
+	folders, found := d.nameIndex[name]
+	if !found {
+		return nil, &FolderError{
+			Err:   ErrFolderNotFound,
+			Name:  name,
+			OrgID: orgId,
+			msg:   fmt.Sprintf("folder '%s' does not exist", name),
+		}
+	}
+	for _, folder := range folders {
+		if folder.OrgId == orgId {
+			return folder, nil
+		}
+	}
+	return nil, &FolderError{
+		Err:   ErrWrongOrg,
+		Name:  name,
+		OrgID: orgId,
+		msg:   fmt.Sprintf("folder '%s' does not exist in org '%s'", name, orgId),
+	}
+}
@@ -0,0 +1,138 @@
+package folder
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Read-only view of one org's folders as directories, see driver.FS
+type folderFS struct {
+	d     *driver
+	orgID uuid.UUID
+}
+
+// Returns the folders of orgID as a read-only file system where every folder is a
+// directory and "a.b.c" is opened as "a/b/c". The result also implements fs.ReadDirFS
+// and fs.StatFS. Each call reads the live tree under the read lock, so changes made
+// through the driver show up in later calls.
+func (d *driver) FS(orgID uuid.UUID) fs.FS {
+	return &folderFS{d: d, orgID: orgID}
+}
+
+func (f *folderFS) Open(name string) (fs.File, error) {
+	info, entries, err := f.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	return &folderFile{info: info, entries: entries}, nil
+}
+
+func (f *folderFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	_, entries, err := f.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (f *folderFS) Stat(name string) (fs.FileInfo, error) {
+	info, _, err := f.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// Resolves name to its folder and returns the folder's info and its children sorted by name
+func (f *folderFS) lookup(op string, name string) (fs.FileInfo, []fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	f.d.mu.RLock()
+	defer f.d.mu.RUnlock()
+
+	var children []*Folder
+	var info fs.FileInfo
+	if name == "." {
+		info = dirInfo{name: "."}
+		for _, folder := range f.d.orgIdIndex[f.orgID] {
+			if folder.Parent == nil {
+				children = append(children, folder)
+			}
+		}
+	} else {
+		// an element holding a '.' would be read as more than one label
+		if strings.Contains(name, ".") {
+			return nil, nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		folder, found := f.d.pathIndex[pathKey{f.orgID, strings.ReplaceAll(name, "/", ".")}]
+		if !found {
+			return nil, nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		info = dirInfo{name: folder.Name}
+		children = folder.Children
+	}
+
+	entries := make([]fs.DirEntry, 0, len(children))
+	for _, child := range children {
+		entries = append(entries, fs.FileInfoToDirEntry(dirInfo{name: child.Name}))
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return info, entries, nil
+}
+
+// An open folder, its entries are fixed when it is opened
+type folderFile struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	// entries already returned by ReadDir
+	offset int
+}
+
+func (f *folderFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *folderFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: f.info.Name(), Err: errors.New("is a directory")}
+}
+
+func (f *folderFile) Close() error {
+	return nil
+}
+
+// Follows fs.ReadDirFile, n <= 0 returns every remaining entry
+func (f *folderFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := f.entries[f.offset:]
+	if n <= 0 {
+		f.offset = len(f.entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(remaining))
+	f.offset += n
+	return remaining[:n], nil
+}
+
+// Folders have no contents or timestamps, so every one is an empty read-only directory
+type dirInfo struct {
+	name string
+}
+
+func (i dirInfo) Name() string       { return i.name }
+func (i dirInfo) Size() int64        { return 0 }
+func (i dirInfo) Mode() fs.FileMode  { return fs.ModeDir | 0o555 }
+func (i dirInfo) ModTime() time.Time { return time.Time{} }
+func (i dirInfo) IsDir() bool        { return true }
+func (i dirInfo) Sys() any           { return nil }
@@ -0,0 +1,142 @@
+package folder_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func newFSTestDriver(t *testing.T, orgId1, orgId2 uuid.UUID) folder.IDriver {
+	t.Helper()
+	driver, err := folder.NewDriver([]folder.Folder{
+		{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+		{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+		{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+		{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+		{Name: "echo", Paths: "echo", OrgId: orgId1},
+		{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+	})
+	if err != nil {
+		t.Fatalf("driver failed to initialise - %v", err)
+	}
+	return driver
+}
+
+func Test_folder_FS(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+	fsys := newFSTestDriver(t, orgId1, orgId2).FS(orgId1)
+
+	t.Run("conforms to testing/fstest", func(t *testing.T) {
+		if err := fstest.TestFS(fsys, "alpha", "alpha/bravo", "alpha/bravo/charlie", "alpha/delta", "echo"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("walks every folder of the org in order", func(t *testing.T) {
+		got := []string{}
+		err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			got = append(got, p)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WalkDir() received unexpected error: %v", err)
+		}
+		want := []string{".", "alpha", "alpha/bravo", "alpha/bravo/charlie", "alpha/delta", "echo"}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("WalkDir() visited %v, want %v", got, want)
+		}
+	})
+
+	t.Run("glob", func(t *testing.T) {
+		got, err := fs.Glob(fsys, "alpha/*")
+		if err != nil {
+			t.Fatalf("Glob() received unexpected error: %v", err)
+		}
+		if strings.Join(got, ",") != "alpha/bravo,alpha/delta" {
+			t.Errorf("Glob() = %v, want [alpha/bravo alpha/delta]", got)
+		}
+	})
+
+	t.Run("missing and invalid paths", func(t *testing.T) {
+		tests := []struct {
+			name string
+			want error
+		}{
+			{name: "foxtrot", want: fs.ErrNotExist},
+			{name: "alpha/charlie", want: fs.ErrNotExist},
+			{name: "alpha.bravo", want: fs.ErrNotExist},
+			{name: "/alpha", want: fs.ErrInvalid},
+			{name: "alpha/../echo", want: fs.ErrInvalid},
+		}
+		for _, tt := range tests {
+			if _, err := fs.Stat(fsys, tt.name); !errors.Is(err, tt.want) {
+				t.Errorf("Stat(%q) error = %v, want %v", tt.name, err, tt.want)
+			}
+			if _, err := fs.ReadDir(fsys, tt.name); !errors.Is(err, tt.want) {
+				t.Errorf("ReadDir(%q) error = %v, want %v", tt.name, err, tt.want)
+			}
+		}
+	})
+
+	t.Run("folders cannot be read as files", func(t *testing.T) {
+		if _, err := fs.ReadFile(fsys, "alpha"); err == nil {
+			t.Errorf("ReadFile() expected an error but got none")
+		}
+	})
+}
+
+func Test_folder_FS_Live(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+	driver := newFSTestDriver(t, orgId1, orgId2)
+	fsys := driver.FS(orgId1)
+
+	if _, err := driver.MoveFolderInOrg(orgId1, "bravo", "echo"); err != nil {
+		t.Fatalf("MoveFolderInOrg() received unexpected error: %v", err)
+	}
+
+	if _, err := fs.Stat(fsys, "echo/bravo/charlie"); err != nil {
+		t.Errorf("Stat() of a moved folder received unexpected error: %v", err)
+	}
+	if _, err := fs.Stat(fsys, "alpha/bravo"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat() of a folder's old path error = %v, want %v", err, fs.ErrNotExist)
+	}
+}
+
+func Test_folder_FS_FileServer(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+	ts := httptest.NewServer(http.FileServer(http.FS(newFSTestDriver(t, orgId1, orgId2).FS(orgId1))))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/alpha/")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /alpha/ status = %d, want %d, body %s", resp.StatusCode, http.StatusOK, body)
+	}
+	for _, want := range []string{`href="bravo/"`, `href="delta/"`} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("GET /alpha/ listing does not contain %s:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package folder
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/lucasepe/codename"
+)
+
+// Inclusive range an integer is drawn from uniformly
+type IntRange struct {
+	Min int
+	Max int
+}
+
+func (r IntRange) sample(rng *rand.Rand) int {
+	return r.Min + rng.Intn(r.Max-r.Min+1)
+}
+
+func (r IntRange) validate(field string, lowest int) error {
+	if r.Min < lowest || r.Max < r.Min {
+		return fmt.Errorf("generate: invalid %s range [%d, %d], need %d <= Min <= Max", field, r.Min, r.Max, lowest)
+	}
+	return nil
+}
+
+// Shape of the data produced by Generate, the same config always produces the same folders
+type GeneratorConfig struct {
+	// Seed of the random source that picks names, org IDs and tree shapes
+	Seed int64
+	// Orgs is the number of orgs, each with its own random org ID
+	Orgs int
+	// RootsPerOrg is the number of root folders in every org
+	RootsPerOrg int
+	// FanOut is the number of children of every folder that is not a leaf
+	FanOut IntRange
+	// Depth is the number of levels of each root's tree, 1 is a lone root folder
+	Depth IntRange
+	// CollisionRate is the chance in [0, 1] that a folder reuses a name from another org,
+	// names stay unique within an org
+	CollisionRate float64
+}
+
+// Returns a config shaped like GenerateData, MaxChild fan-out and MaxDepth levels
+func DefaultGeneratorConfig(seed int64) GeneratorConfig {
+	return GeneratorConfig{
+		Seed:        seed,
+		Orgs:        2,
+		RootsPerOrg: MaxRootSet / 2,
+		FanOut:      IntRange{Min: 1, Max: MaxChild},
+		Depth:       IntRange{Min: MaxDepth, Max: MaxDepth},
+	}
+}
+
+func (c GeneratorConfig) validate() error {
+	if c.Orgs < 0 {
+		return fmt.Errorf("generate: Orgs cannot be negative, got %d", c.Orgs)
+	}
+	if c.RootsPerOrg < 0 {
+		return fmt.Errorf("generate: RootsPerOrg cannot be negative, got %d", c.RootsPerOrg)
+	}
+	if err := c.FanOut.validate("FanOut", 0); err != nil {
+		return err
+	}
+	if err := c.Depth.validate("Depth", 1); err != nil {
+		return err
+	}
+	if c.CollisionRate < 0 || c.CollisionRate > 1 {
+		return fmt.Errorf("generate: CollisionRate must be within [0, 1], got %v", c.CollisionRate)
+	}
+	return nil
+}
+
+// Generates a reproducible set of folders, parents before children, that NewDriver accepts
+func Generate(cfg GeneratorConfig) ([]Folder, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	folders := []Folder{}
+	// names used by earlier orgs, the pool collisions are drawn from
+	usedNames := []string{}
+
+	for i := 0; i < cfg.Orgs; i++ {
+		orgID := randomOrgID(rng)
+		names := &orgNamer{rng: rng, taken: make(map[string]bool), pool: usedNames, collisionRate: cfg.CollisionRate}
+
+		for j := 0; j < cfg.RootsPerOrg; j++ {
+			depth := cfg.Depth.sample(rng)
+			root := Folder{Name: names.next(), OrgId: orgID}
+			root.Paths = root.Name
+
+			// depth first with an explicit stack, children are pushed in reverse so they pop in order
+			stack := []generatorFrame{{root, 1}}
+			for len(stack) > 0 {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				folders = append(folders, top.folder)
+				if top.level >= depth {
+					continue
+				}
+
+				children := make([]Folder, cfg.FanOut.sample(rng))
+				for k := range children {
+					name := names.next()
+					children[k] = Folder{Name: name, OrgId: orgID, Paths: top.folder.Paths + "." + name}
+				}
+				for k := len(children) - 1; k >= 0; k-- {
+					stack = append(stack, generatorFrame{children[k], top.level + 1})
+				}
+			}
+		}
+
+		usedNames = append(usedNames, names.order...)
+	}
+
+	return folders, nil
+}
+
+// Folder waiting on the stack of Generate with its level in the tree
+type generatorFrame struct {
+	folder Folder
+	level  int
+}
+
+// Random version 4 org ID drawn from rng rather than crypto/rand, so it is reproducible
+func randomOrgID(rng *rand.Rand) uuid.UUID {
+	var id uuid.UUID
+	rng.Read(id[:])
+	id.SetVersion(uuid.V4)
+	id.SetVariant(uuid.VariantRFC4122)
+	return id
+}
+
+// Hands out names that are unique within a single org
+type orgNamer struct {
+	rng   *rand.Rand
+	taken map[string]bool
+	// taken names in the order they were handed out, map order is random
+	order []string
+	// names from other orgs that may be reused
+	pool          []string
+	collisionRate float64
+}
+
+// codename has a few thousand combinations, so after a few misses a counter is appended instead
+const maxNameAttempts = 8
+
+func (n *orgNamer) next() string {
+	if len(n.pool) > 0 && n.rng.Float64() < n.collisionRate {
+		if name := n.pool[n.rng.Intn(len(n.pool))]; !n.taken[name] {
+			n.take(name)
+			return name
+		}
+	}
+
+	name := codename.Generate(n.rng, 0)
+	for attempt := 1; n.taken[name]; attempt++ {
+		if attempt < maxNameAttempts {
+			name = codename.Generate(n.rng, 0)
+		} else {
+			name = name + "-" + strconv.Itoa(len(n.taken))
+		}
+	}
+	n.take(name)
+	return name
+}
+
+func (n *orgNamer) take(name string) {
+	n.taken[name] = true
+	n.order = append(n.order, name)
+}
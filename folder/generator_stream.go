@@ -0,0 +1,241 @@
+package folder
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/gofrs/uuid"
+	"github.com/lucasepe/codename"
+)
+
+// Size a worker fills before handing its output to the writer
+const streamChunkSize = 64 << 10
+
+// Level at which a root tree is cut into subtrees, the folders down to it are generated
+// up front and every folder on it that has children becomes a job of its own, so even a
+// single root keeps every worker busy
+const streamSplitLevel = 3
+
+// One subtree for a worker to generate below a folder that was already written
+type streamJob struct {
+	orgID uuid.UUID
+	org   int
+	root  int
+	// position of the subtree within its root tree, part of its seed and names
+	sub   int
+	frame generatorFrame
+	depth int
+}
+
+// Encoded JSON lines ready to be written
+type streamChunk struct {
+	data  []byte
+	count int64
+}
+
+// Writes the folders described by cfg to w as JSON lines, one folder per line, and
+// returns how many were written. The top levels of every root tree are written first and
+// the subtrees below them are generated by a pool of workers, 0 uses GOMAXPROCS, so memory
+// stays bounded by the number of workers rather than the output.
+//
+// Every root tree and subtree is generated from its own seed, so a config always produces
+// the same set of folders, but subtrees from different workers interleave in any order.
+// Parents are always written before their children.
+//
+// Names are made unique per org by suffixing the root, subtree and folder number, a folder
+// that collides takes a name shared by the same position in every org.
+func GenerateStream(w io.Writer, cfg GeneratorConfig, workers int) (int64, error) {
+	if err := cfg.validate(); err != nil {
+		return 0, err
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	orgIDs := make([]uuid.UUID, cfg.Orgs)
+	for i := range orgIDs {
+		orgIDs[i] = randomOrgID(rng)
+	}
+
+	jobs := make(chan streamJob)
+	chunks := make(chan streamChunk, workers)
+	// closed when writing fails so workers stop early
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		for org, orgID := range orgIDs {
+			for root := 0; root < cfg.RootsPerOrg; root++ {
+				// the top is sent before its subtrees are queued, so parents come first
+				top, subtrees := generateStreamTop(cfg, orgID, org, root)
+				select {
+				case chunks <- top:
+				case <-done:
+					return
+				}
+				for _, job := range subtrees {
+					select {
+					case jobs <- job:
+					case <-done:
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if !generateStreamSubtree(cfg, job, chunks, done) {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(chunks)
+	}()
+
+	var written int64
+	var writeErr error
+	for chunk := range chunks {
+		// keep draining so blocked workers can see done and exit
+		if writeErr != nil {
+			continue
+		}
+		if _, err := w.Write(chunk.data); err != nil {
+			writeErr = err
+			close(done)
+			continue
+		}
+		written += chunk.count
+	}
+	return written, writeErr
+}
+
+// Generates the levels of one root tree down to streamSplitLevel, returns them encoded
+// along with a job for every subtree left to generate below them
+func generateStreamTop(cfg GeneratorConfig, orgID uuid.UUID, org int, root int) (streamChunk, []streamJob) {
+	rng := rand.New(rand.NewSource(streamSeed(cfg.Seed, org, root)))
+	depth := cfg.Depth.sample(rng)
+	name := streamNaming(cfg, rng, "-"+strconv.Itoa(root))
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	var count int64
+	jobs := []streamJob{}
+	rootName := name()
+	top := generatorFrame{Folder{Name: rootName, OrgId: orgID, Paths: rootName}, 1}
+	generateStreamFrames(cfg, rng, top, min(depth, streamSplitLevel), name, func(f generatorFrame) bool {
+		// Encode only fails on values that cannot be marshalled, which a Folder never holds
+		_ = enc.Encode(f.folder)
+		count++
+		if f.level == streamSplitLevel && f.level < depth {
+			jobs = append(jobs, streamJob{orgID: orgID, org: org, root: root, sub: len(jobs), frame: f, depth: depth})
+		}
+		return true
+	})
+	return streamChunk{data: buf.Bytes(), count: count}, jobs
+}
+
+// Generates the folders below the frame of job depth first and sends them to chunks,
+// returns false if done was closed before everything was sent
+func generateStreamSubtree(cfg GeneratorConfig, job streamJob, chunks chan<- streamChunk, done <-chan struct{}) bool {
+	rng := rand.New(rand.NewSource(streamSeed(cfg.Seed, job.org, job.root, job.sub)))
+	name := streamNaming(cfg, rng, "-"+strconv.Itoa(job.root)+"-"+strconv.Itoa(job.sub))
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	var count int64
+	flush := func() bool {
+		select {
+		case chunks <- streamChunk{data: buf.Bytes(), count: count}:
+		case <-done:
+			return false
+		}
+		buf = &bytes.Buffer{}
+		enc = json.NewEncoder(buf)
+		count = 0
+		return true
+	}
+
+	ok := generateStreamFrames(cfg, rng, job.frame, job.depth, name, func(f generatorFrame) bool {
+		// the frame itself was written with the top of the tree
+		if f.level == job.frame.level {
+			return true
+		}
+		_ = enc.Encode(f.folder)
+		count++
+		return buf.Len() < streamChunkSize || flush()
+	})
+	if ok && count > 0 {
+		return flush()
+	}
+	return ok
+}
+
+// Expands start depth first down to maxLevel, handing every folder to emit in the order
+// it is written. Returns false as soon as emit does.
+func generateStreamFrames(cfg GeneratorConfig, rng *rand.Rand, start generatorFrame, maxLevel int, name func() string, emit func(generatorFrame) bool) bool {
+	stack := []generatorFrame{start}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !emit(top) {
+			return false
+		}
+
+		if top.level >= maxLevel {
+			continue
+		}
+		children := make([]generatorFrame, cfg.FanOut.sample(rng))
+		for k := range children {
+			childName := name()
+			children[k] = generatorFrame{Folder{Name: childName, OrgId: top.folder.OrgId, Paths: top.folder.Paths + "." + childName}, top.level + 1}
+		}
+		// pushed in reverse so they pop in order
+		for k := len(children) - 1; k >= 0; k-- {
+			stack = append(stack, children[k])
+		}
+	}
+	return true
+}
+
+// Numbers every folder it names, suffix and folder number make names unique within the org
+func streamNaming(cfg GeneratorConfig, rng *rand.Rand, suffix string) func() string {
+	next := 0
+	return func() string {
+		n := next
+		next++
+		if rng.Float64() < cfg.CollisionRate {
+			return "folder" + suffix + "-" + strconv.Itoa(n)
+		}
+		return codename.Generate(rng, 0) + suffix + "-" + strconv.Itoa(n)
+	}
+}
+
+// Seed of a root tree or subtree at the given position, mixed with splitmix64 so nearby
+// positions get unrelated seeds
+func streamSeed(seed int64, position ...int) int64 {
+	x := uint64(seed)
+	for _, v := range position {
+		x += uint64(v) + 0x9e3779b97f4a7c15
+		x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+		x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+		x ^= x >> 31
+	}
+	return int64(x)
+}
@@ -0,0 +1,215 @@
+package folder_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+// decodes JSON lines, failing the test on a malformed line
+func decodeLines(t *testing.T, data []byte) []folder.Folder {
+	t.Helper()
+	folders := []folder.Folder{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		f := folder.Folder{}
+		if err := json.Unmarshal(scanner.Bytes(), &f); err != nil {
+			t.Fatalf("line %d is not a folder: %v, %s", len(folders)+1, err, scanner.Bytes())
+		}
+		folders = append(folders, f)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return folders
+}
+
+func Test_folder_GenerateStream(t *testing.T) {
+	cfg := folder.GeneratorConfig{
+		Seed:          11,
+		Orgs:          3,
+		RootsPerOrg:   4,
+		FanOut:        folder.IntRange{Min: 1, Max: 4},
+		Depth:         folder.IntRange{Min: 2, Max: 5},
+		CollisionRate: 0.5,
+	}
+
+	var buf bytes.Buffer
+	n, err := folder.GenerateStream(&buf, cfg, 4)
+	if err != nil {
+		t.Fatalf("GenerateStream() received unexpected error: %v", err)
+	}
+	folders := decodeLines(t, buf.Bytes())
+	if int64(len(folders)) != n {
+		t.Errorf("GenerateStream() reported %d folders but wrote %d", n, len(folders))
+	}
+
+	t.Run("parents are written before their children", func(t *testing.T) {
+		seen := make(map[string]bool)
+		for _, f := range folders {
+			key := f.OrgId.String() + "/" + f.Paths
+			if parent := f.Paths[:max(strings.LastIndex(f.Paths, "."), 0)]; parent != "" && !seen[f.OrgId.String()+"/"+parent] {
+				t.Errorf("folder %s was written before its parent", f.Paths)
+			}
+			seen[key] = true
+		}
+	})
+
+	t.Run("output loads into a driver", func(t *testing.T) {
+		if _, err := folder.NewDriver(folders); err != nil {
+			t.Errorf("NewDriver() rejected generated folders: %v", err)
+		}
+	})
+
+	t.Run("shape follows the config", func(t *testing.T) {
+		roots := make(map[uuid.UUID]int)
+		shared := 0
+		orgsByName := make(map[string]int)
+		for _, f := range folders {
+			if levels := strings.Count(f.Paths, ".") + 1; levels > 5 {
+				t.Errorf("folder %s is %d levels deep, want at most 5", f.Paths, levels)
+			} else if levels == 1 {
+				roots[f.OrgId]++
+			}
+			if orgsByName[f.Name]++; orgsByName[f.Name] == 2 {
+				shared++
+			}
+		}
+		if len(roots) != 3 {
+			t.Errorf("GenerateStream() produced %d orgs, want 3", len(roots))
+		}
+		for orgID, n := range roots {
+			if n != 4 {
+				t.Errorf("org %s has %d roots, want 4", orgID, n)
+			}
+		}
+		if shared == 0 {
+			t.Errorf("GenerateStream() with CollisionRate 0.5 shared no names between orgs")
+		}
+	})
+
+	t.Run("same folders regardless of worker count", func(t *testing.T) {
+		var serial bytes.Buffer
+		if _, err := folder.GenerateStream(&serial, cfg, 1); err != nil {
+			t.Fatalf("GenerateStream() received unexpected error: %v", err)
+		}
+		lines := func(data []byte) []string {
+			l := strings.Split(strings.TrimSpace(string(data)), "\n")
+			sort.Strings(l)
+			return l
+		}
+		if strings.Join(lines(serial.Bytes()), "\n") != strings.Join(lines(buf.Bytes()), "\n") {
+			t.Errorf("GenerateStream() with 1 and 4 workers produced different folders")
+		}
+	})
+}
+
+// keeps every write separately
+type recordingWriter struct {
+	writes [][]byte
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, append([]byte(nil), p...))
+	return len(p), nil
+}
+
+func Test_folder_GenerateStream_SingleRoot(t *testing.T) {
+	cfg := folder.GeneratorConfig{
+		Seed:        3,
+		Orgs:        1,
+		RootsPerOrg: 1,
+		FanOut:      folder.IntRange{Min: 3, Max: 3},
+		Depth:       folder.IntRange{Min: 5, Max: 5},
+	}
+
+	w := &recordingWriter{}
+	n, err := folder.GenerateStream(w, cfg, 4)
+	if err != nil {
+		t.Fatalf("GenerateStream() received unexpected error: %v", err)
+	}
+	// 1 + 3 + 9 + 27 + 81 folders
+	if n != 121 {
+		t.Errorf("GenerateStream() wrote %d folders, want 121", n)
+	}
+
+	// the first write holds the top of the tree, every later one a subtree handed to a
+	// worker, so the 9 folders on level 3 give at least 9 more writes
+	if len(w.writes) < 10 {
+		t.Fatalf("GenerateStream() wrote a single root in %d chunks, want it split between workers", len(w.writes))
+	}
+	subtrees := make(map[string]bool)
+	for i, data := range w.writes[1:] {
+		prefixes := make(map[string]bool)
+		for _, f := range decodeLines(t, data) {
+			labels := strings.Split(f.Paths, ".")
+			if len(labels) <= 3 {
+				t.Errorf("chunk %d holds %s from the top of the tree", i+1, f.Paths)
+				continue
+			}
+			prefixes[strings.Join(labels[:3], ".")] = true
+		}
+		if len(prefixes) != 1 {
+			t.Errorf("chunk %d holds %d subtrees, want 1", i+1, len(prefixes))
+		}
+		for prefix := range prefixes {
+			subtrees[prefix] = true
+		}
+	}
+	if len(subtrees) != 9 {
+		t.Errorf("GenerateStream() generated %d subtrees, want 9", len(subtrees))
+	}
+}
+
+// fails every write after the first
+type failingWriter struct {
+	writes int
+}
+
+var errWriteFailed = errors.New("disk full")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes > 1 {
+		return 0, errWriteFailed
+	}
+	return len(p), nil
+}
+
+func Test_folder_GenerateStream_WriteError(t *testing.T) {
+	cfg := folder.GeneratorConfig{
+		Seed:        5,
+		Orgs:        4,
+		RootsPerOrg: 50,
+		FanOut:      folder.IntRange{Min: 3, Max: 3},
+		Depth:       folder.IntRange{Min: 6, Max: 6},
+	}
+
+	w := &failingWriter{}
+	n, err := folder.GenerateStream(w, cfg, 8)
+	if !errors.Is(err, errWriteFailed) {
+		t.Fatalf("GenerateStream() error = %v, want %v", err, errWriteFailed)
+	}
+	if n == 0 {
+		t.Errorf("GenerateStream() reported no folders written before the failure")
+	}
+}
+
+func Test_folder_GenerateStream_InvalidConfig(t *testing.T) {
+	cfg := folder.DefaultGeneratorConfig(1)
+	cfg.Depth = folder.IntRange{Min: 0, Max: 0}
+	var buf bytes.Buffer
+	if _, err := folder.GenerateStream(&buf, cfg, 2); err == nil {
+		t.Errorf("GenerateStream() expected an error but got none")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("GenerateStream() wrote %d bytes for an invalid config", buf.Len())
+	}
+}
@@ -0,0 +1,160 @@
+package folder_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_Generate(t *testing.T) {
+	t.Run("same seed produces the same folders", func(t *testing.T) {
+		cfg := folder.DefaultGeneratorConfig(42)
+		cfg.CollisionRate = 0.3
+		a, err := folder.Generate(cfg)
+		if err != nil {
+			t.Fatalf("Generate() received unexpected error: %v", err)
+		}
+		b, err := folder.Generate(cfg)
+		if err != nil {
+			t.Fatalf("Generate() received unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("Generate() with the same config returned different folders")
+		}
+
+		cfg.Seed = 43
+		c, err := folder.Generate(cfg)
+		if err != nil {
+			t.Fatalf("Generate() received unexpected error: %v", err)
+		}
+		if reflect.DeepEqual(a, c) {
+			t.Errorf("Generate() with different seeds returned the same folders")
+		}
+	})
+
+	t.Run("shape follows the config", func(t *testing.T) {
+		cfg := folder.GeneratorConfig{
+			Seed:        7,
+			Orgs:        3,
+			RootsPerOrg: 2,
+			FanOut:      folder.IntRange{Min: 2, Max: 2},
+			Depth:       folder.IntRange{Min: 3, Max: 3},
+		}
+		folders, err := folder.Generate(cfg)
+		if err != nil {
+			t.Fatalf("Generate() received unexpected error: %v", err)
+		}
+		// every root has 2 children and 4 grandchildren
+		if len(folders) != 3*2*7 {
+			t.Errorf("Generate() returned %d folders, want %d", len(folders), 3*2*7)
+		}
+
+		roots := make(map[uuid.UUID]int)
+		for _, f := range folders {
+			levels := strings.Count(f.Paths, ".") + 1
+			if levels > 3 {
+				t.Errorf("folder %s is %d levels deep, want at most 3", f.Paths, levels)
+			}
+			if !strings.HasSuffix("."+f.Paths, "."+f.Name) {
+				t.Errorf("folder %s has path %s not ending in its name", f.Name, f.Paths)
+			}
+			if levels == 1 {
+				roots[f.OrgId]++
+			}
+		}
+		if len(roots) != 3 {
+			t.Errorf("Generate() produced %d orgs, want 3", len(roots))
+		}
+		for orgID, n := range roots {
+			if n != 2 {
+				t.Errorf("org %s has %d roots, want 2", orgID, n)
+			}
+		}
+
+		if _, err := folder.NewDriver(folders); err != nil {
+			t.Errorf("NewDriver() rejected generated folders: %v", err)
+		}
+	})
+
+	t.Run("collisions reuse names across orgs only", func(t *testing.T) {
+		cfg := folder.GeneratorConfig{
+			Seed:          3,
+			Orgs:          2,
+			RootsPerOrg:   1,
+			FanOut:        folder.IntRange{Min: 3, Max: 3},
+			Depth:         folder.IntRange{Min: 3, Max: 3},
+			CollisionRate: 1,
+		}
+		folders, err := folder.Generate(cfg)
+		if err != nil {
+			t.Fatalf("Generate() received unexpected error: %v", err)
+		}
+
+		orgsByName := make(map[string][]uuid.UUID)
+		for _, f := range folders {
+			orgsByName[f.Name] = append(orgsByName[f.Name], f.OrgId)
+		}
+		shared := 0
+		for name, orgs := range orgsByName {
+			if len(orgs) == 2 && orgs[0] == orgs[1] {
+				t.Errorf("name %s is used twice in org %s", name, orgs[0])
+			}
+			if len(orgs) > 1 {
+				shared++
+			}
+		}
+		if shared == 0 {
+			t.Errorf("Generate() with CollisionRate 1 shared no names between orgs")
+		}
+		if _, err := folder.NewDriver(folders); err != nil {
+			t.Errorf("NewDriver() rejected generated folders: %v", err)
+		}
+	})
+
+	t.Run("large orgs keep names unique", func(t *testing.T) {
+		cfg := folder.GeneratorConfig{
+			Seed:        1,
+			Orgs:        1,
+			RootsPerOrg: 1,
+			FanOut:      folder.IntRange{Min: 30, Max: 30},
+			Depth:       folder.IntRange{Min: 3, Max: 3},
+		}
+		folders, err := folder.Generate(cfg)
+		if err != nil {
+			t.Fatalf("Generate() received unexpected error: %v", err)
+		}
+		if _, err := folder.NewDriver(folders); err != nil {
+			t.Errorf("NewDriver() rejected generated folders: %v", err)
+		}
+	})
+}
+
+func Test_folder_Generate_InvalidConfig(t *testing.T) {
+	valid := folder.DefaultGeneratorConfig(1)
+
+	tests := []struct {
+		name   string
+		modify func(*folder.GeneratorConfig)
+	}{
+		{name: "negative orgs", modify: func(c *folder.GeneratorConfig) { c.Orgs = -1 }},
+		{name: "negative roots", modify: func(c *folder.GeneratorConfig) { c.RootsPerOrg = -1 }},
+		{name: "inverted fan-out", modify: func(c *folder.GeneratorConfig) { c.FanOut = folder.IntRange{Min: 3, Max: 1} }},
+		{name: "zero depth", modify: func(c *folder.GeneratorConfig) { c.Depth = folder.IntRange{Min: 0, Max: 2} }},
+		{name: "collision rate above one", modify: func(c *folder.GeneratorConfig) { c.CollisionRate = 1.5 }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			if _, err := folder.Generate(cfg); err == nil {
+				t.Errorf("Generate() expected an error but got none")
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package folder
 
 import (
 	"fmt"
+	"sort"
+
 	"github.com/gofrs/uuid"
 )
 
@@ -10,6 +12,9 @@ func GetAllFolders() []Folder {
 }
 
 func (d *driver) GetFoldersByOrgID(orgID uuid.UUID) []Folder {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
 	folders := d.folders
 
 	res := []Folder{}
@@ -28,41 +33,103 @@ func (driver *driver) GetAllChildFolders(orgID uuid.UUID, name string) ([]Folder
 	defer driver.mu.RUnlock()
 
 	if orgID == uuid.Nil {
-		return nil, fmt.Errorf("GetAllChildFolders: invalid OrgID - '%s'", orgID)
+		return nil, invalidOrgIDError("GetAllChildFolders", orgID)
 	}
 
-	// get a list of names from the map and filter by orgId
-	folders, found := driver.nameIndex[name]
-	if !found {
-		return nil, fmt.Errorf("GetAllChildFolders: folder '%s' does not exist", name)
+	// names are unique per org, so at most one folder matches
+	parentFolder, err := driver.getFolderInOrg(orgID, name)
+	if err != nil {
+		return nil, fmt.Errorf("GetAllChildFolders: %w", err)
 	}
 
-	// check if duplicate names exist in this
-	orgIdFound := false
-	var parentFolder *Folder
-	for _, folder := range folders {
-		if folder.OrgId == orgID {
-			if orgIdFound {
-				return nil, fmt.Errorf("GetAllChildrenFolders: there exists more than one '%s' in org %s", name, orgID)
-			}
-			orgIdFound = true
-			parentFolder = folder
+	allChildren, err := collectAllDescendents(parentFolder)
+	if err != nil {
+		return nil, fmt.Errorf("GetAllChildFolders: %w", err)
+	}
+	if len(allChildren) == 0 {
+		driver.logger.Debug("GetAllChildFolders: folder has no children", "name", name, "org_id", orgID)
+	}
+
+	return allChildren, nil
+}
+
+// Order in which GetChildFolders walks a subtree
+type TraversalOrder int
+
+const (
+	// PreOrder lists each folder directly before its own descendents
+	PreOrder TraversalOrder = iota
+	// BreadthFirst lists every folder at one depth before any folder at the next
+	BreadthFirst
+)
+
+func (o TraversalOrder) String() string {
+	switch o {
+	case PreOrder:
+		return "preorder"
+	case BreadthFirst:
+		return "breadthfirst"
+	}
+	return fmt.Sprintf("TraversalOrder(%d)", int(o))
+}
+
+// Parses the String form of a TraversalOrder
+func ParseTraversalOrder(s string) (TraversalOrder, error) {
+	for _, o := range []TraversalOrder{PreOrder, BreadthFirst} {
+		if o.String() == s {
+			return o, nil
 		}
 	}
+	return PreOrder, fmt.Errorf("unknown traversal order '%s', expected preorder or breadthfirst", s)
+}
 
-	if !orgIdFound {
-		return nil, fmt.Errorf("GetAllChildFolders: folder '%s' does not exist in org '%s'", name, orgID)
+// Returns the descendents of name down to maxDepth levels, 1 returns only direct children and 0 is unlimited.
+// Siblings are visited in name order so the result is deterministic for either traversal order.
+func (d *driver) GetChildFolders(orgID uuid.UUID, name string, maxDepth int, order TraversalOrder) ([]Folder, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if orgID == uuid.Nil {
+		return nil, invalidOrgIDError("getChildFolders", orgID)
+	}
+	if maxDepth < 0 {
+		return nil, fmt.Errorf("getChildFolders: maxDepth cannot be negative, got %d", maxDepth)
 	}
 
-	allChildren, err := collectAllDescendents(parentFolder)
+	parent, err := d.getFolderInOrg(orgID, name)
 	if err != nil {
-		return nil, fmt.Errorf("GetAllChildrenFolders: there exists more than one '%s' in org '%s'", name, orgID)
+		return nil, fmt.Errorf("getChildFolders: %w", err)
 	}
-	if len(allChildren) == 0 {
-		fmt.Printf("GetAllChildrenFolders: '%s' has no children folders\n", name)
+
+	switch order {
+	case PreOrder:
+		return collectPreOrder(parent, maxDepth), nil
+	case BreadthFirst:
+		return collectBreadthFirst(parent, maxDepth), nil
+	}
+	return nil, fmt.Errorf("getChildFolders: unknown traversal order %s", order)
+}
+
+// Returns the folder stored at path within orgID
+func (d *driver) GetFolderByPath(orgID uuid.UUID, path string) (Folder, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if orgID == uuid.Nil {
+		return Folder{}, invalidOrgIDError("GetFolderByPath", orgID)
 	}
 
-	return allChildren, nil
+	folder, found := d.pathIndex[pathKey{orgID, path}]
+	if !found {
+		return Folder{}, &FolderError{
+			Err:   ErrFolderNotFound,
+			OrgID: orgID,
+			Path:  path,
+			msg:   fmt.Sprintf("GetFolderByPath: path '%s' does not exist in org '%s'", path, orgID),
+		}
+	}
+
+	return *folder, nil
 }
 
 // Recursive function to collect all children
@@ -80,3 +147,51 @@ func collectAllDescendents(parent *Folder) ([]Folder, error) {
 	recursiveCollect(parent)
 	return allChildren, nil
 }
+
+// Collects descendents depth first, a maxDepth of 0 is unlimited
+func collectPreOrder(parent *Folder, maxDepth int) []Folder {
+	res := []Folder{}
+	var visit func(*Folder, int)
+	visit = func(currFolder *Folder, depth int) {
+		if maxDepth != 0 && depth > maxDepth {
+			return
+		}
+		for _, child := range sortedChildren(currFolder) {
+			res = append(res, *child)
+			visit(child, depth+1)
+		}
+	}
+
+	visit(parent, 1)
+	return res
+}
+
+// Collects descendents level by level, a maxDepth of 0 is unlimited
+func collectBreadthFirst(parent *Folder, maxDepth int) []Folder {
+	res := []Folder{}
+	level := []*Folder{parent}
+	for depth := 1; len(level) > 0 && (maxDepth == 0 || depth <= maxDepth); depth++ {
+		next := []*Folder{}
+		for _, currFolder := range level {
+			for _, child := range sortedChildren(currFolder) {
+				res = append(res, *child)
+				next = append(next, child)
+			}
+		}
+		level = next
+	}
+	return res
+}
+
+// Returns a copy of folder.Children ordered by name
+func sortedChildren(folder *Folder) []*Folder {
+	children := append([]*Folder(nil), folder.Children...)
+	sortFoldersByName(children)
+	return children
+}
+
+func sortFoldersByName(folders []*Folder) {
+	sort.Slice(folders, func(i, j int) bool {
+		return folders[i].Name < folders[j].Name
+	})
+}
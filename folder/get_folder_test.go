@@ -1,6 +1,7 @@
 package folder_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/georgechieng-sc/interns-2022/folder"
@@ -127,6 +128,21 @@ func TestGetAllChildFolders(t *testing.T) {
 			wantInitError: false,
 		},
 
+		{
+			name:   "identical root paths in different organizations keep their own children",
+			orgID:  orgID2,
+			parent: "alpha",
+			want: []folder.Folder{
+				{Name: "hotel", OrgId: orgID2, Paths: "alpha.hotel"},
+			},
+			wantRunError:  false,
+			wantInitError: false,
+			extraFolders: []folder.Folder{
+				{Name: "alpha", OrgId: orgID2, Paths: "alpha"},
+				{Name: "hotel", OrgId: orgID2, Paths: "alpha.hotel"},
+			},
+		},
+
 		// edge cases
 		{
 			name:  "access a parent folder that belongs to a different org",
@@ -261,3 +277,229 @@ func TestGetAllChildFolders(t *testing.T) {
 		})
 	}
 }
+
+func Test_folder_GetFolderByPath(t *testing.T) {
+	orgID1 := uuid.Must(uuid.NewV4())
+	orgID2 := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name         string
+		orgID        uuid.UUID
+		path         string
+		want         folder.Folder
+		wantRunError bool
+	}{
+		{
+			name:  "root path",
+			orgID: orgID1,
+			path:  "alpha",
+			want:  folder.Folder{Name: "alpha", OrgId: orgID1, Paths: "alpha"},
+		},
+		{
+			name:  "identical root path in a different org",
+			orgID: orgID2,
+			path:  "alpha",
+			want:  folder.Folder{Name: "alpha", OrgId: orgID2, Paths: "alpha"},
+		},
+		{
+			name:  "nested path",
+			orgID: orgID2,
+			path:  "alpha.charlie",
+			want:  folder.Folder{Name: "charlie", OrgId: orgID2, Paths: "alpha.charlie"},
+		},
+
+		// edge cases
+		{
+			name:         "path that only exists in a different org",
+			orgID:        orgID1,
+			path:         "alpha.charlie",
+			wantRunError: true,
+		},
+		{
+			name:         "partial path",
+			orgID:        orgID1,
+			path:         "bravo",
+			wantRunError: true,
+		},
+		{
+			name:         "OrgID is invalid",
+			orgID:        uuid.Nil,
+			path:         "alpha",
+			wantRunError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := folder.NewDriver([]folder.Folder{
+				{Name: "alpha", OrgId: orgID1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgID1, Paths: "alpha.bravo"},
+				{Name: "alpha", OrgId: orgID2, Paths: "alpha"},
+				{Name: "charlie", OrgId: orgID2, Paths: "alpha.charlie"},
+			})
+			if err != nil {
+				t.Fatalf("driver failed to initialise - %v", err)
+			}
+
+			got, err := driver.GetFolderByPath(tt.orgID, tt.path)
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("GetFolderByPath() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if tt.wantRunError {
+				return
+			}
+
+			if !compareFolders([]folder.Folder{got}, []folder.Folder{tt.want}) {
+				t.Errorf("GetFolderByPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_folder_GetChildFolders(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	// children are declared out of name order to check siblings get sorted
+	folders := []folder.Folder{
+		{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+		{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+		{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+		{Name: "echo", Paths: "alpha.delta.echo", OrgId: orgId1},
+		{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+		{Name: "golf", Paths: "alpha.bravo.charlie.golf", OrgId: orgId1},
+		{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+	}
+
+	tests := []struct {
+		name         string
+		orgID        uuid.UUID
+		folderName   string
+		maxDepth     int
+		order        folder.TraversalOrder
+		want         []string
+		wantRunError bool
+	}{
+		// Functionalities
+		{
+			name:       "direct children",
+			orgID:      orgId1,
+			folderName: "alpha",
+			maxDepth:   1,
+			want:       []string{"bravo", "delta"},
+		},
+		{
+			name:       "unlimited depth in pre-order",
+			orgID:      orgId1,
+			folderName: "alpha",
+			want:       []string{"bravo", "charlie", "golf", "delta", "echo"},
+		},
+		{
+			name:       "unlimited depth breadth first",
+			orgID:      orgId1,
+			folderName: "alpha",
+			order:      folder.BreadthFirst,
+			want:       []string{"bravo", "delta", "charlie", "echo", "golf"},
+		},
+		{
+			name:       "two levels in pre-order",
+			orgID:      orgId1,
+			folderName: "alpha",
+			maxDepth:   2,
+			want:       []string{"bravo", "charlie", "delta", "echo"},
+		},
+		{
+			name:       "two levels breadth first",
+			orgID:      orgId1,
+			folderName: "alpha",
+			maxDepth:   2,
+			order:      folder.BreadthFirst,
+			want:       []string{"bravo", "delta", "charlie", "echo"},
+		},
+		{
+			name:       "depth beyond the tree",
+			orgID:      orgId1,
+			folderName: "bravo",
+			maxDepth:   10,
+			want:       []string{"charlie", "golf"},
+		},
+		{
+			name:       "leaf folder",
+			orgID:      orgId1,
+			folderName: "golf",
+			maxDepth:   1,
+			want:       []string{},
+		},
+
+		// error checking
+		{
+			name:         "negative depth",
+			orgID:        orgId1,
+			folderName:   "alpha",
+			maxDepth:     -1,
+			wantRunError: true,
+		},
+		{
+			name:         "unknown order",
+			orgID:        orgId1,
+			folderName:   "alpha",
+			order:        folder.TraversalOrder(5),
+			wantRunError: true,
+		},
+		{
+			name:         "folder in another org",
+			orgID:        orgId1,
+			folderName:   "foxtrot",
+			wantRunError: true,
+		},
+		{
+			name:         "invalid orgID",
+			orgID:        uuid.Nil,
+			folderName:   "alpha",
+			wantRunError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := folder.NewDriver(folders)
+			if err != nil {
+				t.Fatalf("driver failed to initialise - %v", err)
+			}
+
+			got, err := driver.GetChildFolders(tt.orgID, tt.folderName, tt.maxDepth, tt.order)
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("GetChildFolders() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if tt.wantRunError {
+				return
+			}
+
+			names := []string{}
+			for _, f := range got {
+				names = append(names, f.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("GetChildFolders() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func Test_folder_ParseTraversalOrder(t *testing.T) {
+	for _, order := range []folder.TraversalOrder{folder.PreOrder, folder.BreadthFirst} {
+		got, err := folder.ParseTraversalOrder(order.String())
+		if err != nil || got != order {
+			t.Errorf("ParseTraversalOrder(%q) = %v, %v, want %v", order.String(), got, err, order)
+		}
+	}
+	if _, err := folder.ParseTraversalOrder("sideways"); err == nil {
+		t.Errorf("ParseTraversalOrder(%q) expected an error", "sideways")
+	}
+}
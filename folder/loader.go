@@ -0,0 +1,179 @@
+package folder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Tuning for LoadDriver
+type LoadOptions struct {
+	// Progress, if set, is called with the number of folders read so far
+	// every ProgressEvery folders and once more when loading finishes
+	Progress      func(loaded int64)
+	ProgressEvery int64
+}
+
+// Decodes folders one at a time and passes each to fn, so a large file is never held in memory.
+// The format is detected from the first byte: a JSON array as in sample.json, or JSON Lines with
+// one folder object per line. Errors give the byte offset of the folder in an array, or its line.
+// Decoding stops at the first error, including one returned by fn.
+func StreamFolders(r io.Reader, fn func(Folder) error) error {
+	br := bufio.NewReader(r)
+	first, skipped, newlines, err := peekNonSpace(br)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("streamFolders: %w", err)
+	}
+
+	// leading whitespace is already consumed, so positions are offset by what was skipped
+	switch first {
+	case '[':
+		return streamArray(br, skipped, fn)
+	case 'n':
+		// json.Unmarshal reads null as an empty slice, and snapshots of an empty driver hold it
+		return streamNull(br, skipped)
+	}
+	return streamLines(br, newlines+1, fn)
+}
+
+func streamNull(r io.Reader, base int64) error {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != nil {
+		return fmt.Errorf("streamFolders: byte offset %d: expected a folder array or folder objects", base)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("streamFolders: byte offset %d: unexpected data after null", base+dec.InputOffset())
+	}
+	return nil
+}
+
+// base is the number of bytes consumed from the input before r
+func streamArray(r io.Reader, base int64, fn func(Folder) error) error {
+	dec := json.NewDecoder(r)
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("streamFolders: byte offset %d: %w", base+dec.InputOffset(), err)
+	}
+
+	for {
+		// measured before More, which may move the decoder past the separator
+		offset := base + valueOffset(dec)
+		if !dec.More() {
+			break
+		}
+		f := Folder{}
+		if err := dec.Decode(&f); err != nil {
+			return fmt.Errorf("streamFolders: folder at byte offset %d: %w", offset, err)
+		}
+		if err := fn(f); err != nil {
+			return fmt.Errorf("streamFolders: folder at byte offset %d: %w", offset, err)
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("streamFolders: byte offset %d: %w", base+dec.InputOffset(), err)
+	}
+	// anything after the closing bracket is an error, not a second document
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("streamFolders: byte offset %d: unexpected data after the folder array", base+dec.InputOffset())
+	}
+	return nil
+}
+
+// firstLine is the line number of the first line read from br
+func streamLines(br *bufio.Reader, firstLine int, fn func(Folder) error) error {
+	for line := firstLine; ; line++ {
+		// ReadBytes has no line length limit, unlike bufio.Scanner
+		b, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("streamFolders: line %d: %w", line, err)
+		}
+		if trimmed := bytes.TrimSpace(b); len(trimmed) > 0 {
+			if trimmed[0] != '{' {
+				return fmt.Errorf("streamFolders: line %d: expected a folder object", line)
+			}
+			f := Folder{}
+			if err := json.Unmarshal(trimmed, &f); err != nil {
+				return fmt.Errorf("streamFolders: line %d: %w", line, err)
+			}
+			if err := fn(f); err != nil {
+				return fmt.Errorf("streamFolders: line %d: %w", line, err)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
+
+// Streams folders from r, in either format StreamFolders accepts, straight into a new driver
+func LoadDriver(r io.Reader, loadOpts LoadOptions, opts ...Option) (IDriver, error) {
+	builder := NewDriverBuilder(opts...)
+	var loaded int64
+	err := StreamFolders(r, func(f Folder) error {
+		if err := builder.Add(f); err != nil {
+			return err
+		}
+		loaded++
+		if loadOpts.Progress != nil && loadOpts.ProgressEvery > 0 && loaded%loadOpts.ProgressEvery == 0 {
+			loadOpts.Progress(loaded)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loadDriver: %w", err)
+	}
+	if loadOpts.Progress != nil {
+		loadOpts.Progress(loaded)
+	}
+
+	d, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("loadDriver: %w", err)
+	}
+	return d, nil
+}
+
+// Byte offset where the decoder's next array element starts, InputOffset stops
+// just after the previous element so the separating comma and spaces are skipped
+func valueOffset(dec *json.Decoder) int64 {
+	offset := dec.InputOffset()
+	// Buffered is a bytes.Reader over data already in memory, read it a byte at a time rather than copying
+	buffered, ok := dec.Buffered().(io.ByteReader)
+	if !ok {
+		return offset
+	}
+	for {
+		c, err := buffered.ReadByte()
+		if err != nil || (c != ',' && c != ' ' && c != '\t' && c != '\r' && c != '\n') {
+			return offset
+		}
+		offset++
+	}
+}
+
+// Returns the first non whitespace byte without consuming it,
+// along with how many bytes and newlines were skipped to reach it
+func peekNonSpace(br *bufio.Reader) (first byte, skipped int64, newlines int, err error) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return 0, skipped, newlines, err
+		}
+		switch b[0] {
+		case '\n':
+			newlines++
+			fallthrough
+		case ' ', '\t', '\r':
+			br.Discard(1)
+			skipped++
+		default:
+			return b[0], skipped, newlines, nil
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package folder_test
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_StreamFolders(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	org := orgId.String()
+	alpha := `{"name": "alpha", "org_id": "` + org + `", "paths": "alpha"}`
+	bravo := `{"name": "bravo", "org_id": "` + org + `", "paths": "alpha.bravo"}`
+	both := []folder.Folder{
+		{Name: "alpha", Paths: "alpha", OrgId: orgId},
+		{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId},
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []folder.Folder
+		wantErr string
+	}{
+		// Functionalities
+		{name: "JSON array", input: "[\n\t" + alpha + ",\n\t" + bravo + "\n]\n", want: both},
+		{name: "JSON lines", input: alpha + "\n" + bravo + "\n", want: both},
+		{name: "JSON lines without a final newline and with blank lines", input: "\n" + alpha + "\n\n" + bravo, want: both},
+		{name: "empty array", input: " [ ] ", want: []folder.Folder{}},
+		{name: "null", input: "null\n", want: []folder.Folder{}},
+		{name: "empty input", input: "", want: []folder.Folder{}},
+
+		// error checking
+		{name: "malformed folder in an array", input: "[" + alpha + `, {"name": 5}]`, wantErr: "folder at byte offset " + strconv.Itoa(len("["+alpha+", "))},
+		{name: "unterminated array", input: "[" + alpha, wantErr: "byte offset"},
+		{name: "data after the array", input: "[" + alpha + "] []", wantErr: "unexpected data after the folder array"},
+		{name: "malformed line", input: alpha + "\n" + `{"name": "bravo"` + "\n", wantErr: "line 2"},
+		{name: "malformed line after leading blank lines", input: "\n\n" + alpha + "\n" + `{"name": "bravo"` + "\n", wantErr: "line 4"},
+		{name: "malformed folder after leading whitespace", input: "\n\n   [" + alpha + `, {"name": 5}]`, wantErr: "folder at byte offset " + strconv.Itoa(len("\n\n   ["+alpha+", "))},
+		{name: "unterminated array after leading whitespace", input: "\n [" + alpha + " x", wantErr: "byte offset " + strconv.Itoa(len("\n ["+alpha+" "))},
+		{name: "line that is not an object", input: alpha + "\n\n[]\n", wantErr: "line 3: expected a folder object"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			got := []folder.Folder{}
+			err := folder.StreamFolders(strings.NewReader(tt.input), func(f folder.Folder) error {
+				got = append(got, f)
+				return nil
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("StreamFolders() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("StreamFolders() received unexpected error: %v", err)
+			}
+			if !compareFolders(got, tt.want) {
+				t.Errorf("StreamFolders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_folder_LoadDriver(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	org := orgId.String()
+
+	t.Run("loads generated JSON lines with progress", func(t *testing.T) {
+		cfg := folder.GeneratorConfig{
+			Seed:        9,
+			Orgs:        2,
+			RootsPerOrg: 3,
+			FanOut:      folder.IntRange{Min: 2, Max: 3},
+			Depth:       folder.IntRange{Min: 3, Max: 4},
+		}
+		var buf bytes.Buffer
+		n, err := folder.GenerateStream(&buf, cfg, 2)
+		if err != nil {
+			t.Fatalf("GenerateStream() received unexpected error: %v", err)
+		}
+
+		generated := decodeLines(t, buf.Bytes())
+
+		progress := []int64{}
+		driver, err := folder.LoadDriver(&buf, folder.LoadOptions{
+			Progress:      func(loaded int64) { progress = append(progress, loaded) },
+			ProgressEvery: 10,
+		})
+		if err != nil {
+			t.Fatalf("LoadDriver() received unexpected error: %v", err)
+		}
+		if len(progress) != int(n/10)+1 || progress[len(progress)-1] != n {
+			t.Errorf("Progress was called with %v, want every 10 folders and a final %d", progress, n)
+		}
+		for i := 1; i < len(progress)-1; i++ {
+			if progress[i] != progress[i-1]+10 {
+				t.Errorf("Progress was called with %v, want every 10 folders", progress)
+				break
+			}
+		}
+
+		orgs := make(map[uuid.UUID]bool)
+		for _, f := range generated {
+			orgs[f.OrgId] = true
+		}
+		loaded := 0
+		for orgID := range orgs {
+			loaded += len(driver.GetFoldersByOrgID(orgID))
+		}
+		if int64(loaded) != n {
+			t.Errorf("driver holds %d folders, want %d", loaded, n)
+		}
+	})
+
+	t.Run("driver errors carry the line", func(t *testing.T) {
+		input := `{"name": "alpha", "org_id": "` + org + `", "paths": "alpha"}` + "\n" +
+			`{"name": "alpha", "org_id": "` + org + `", "paths": "alpha"}` + "\n"
+		_, err := folder.LoadDriver(strings.NewReader(input), folder.LoadOptions{})
+		if !errors.Is(err, folder.ErrDuplicateName) {
+			t.Errorf("LoadDriver() error = %v, want %v", err, folder.ErrDuplicateName)
+		}
+		if err == nil || !strings.Contains(err.Error(), "line 2") {
+			t.Errorf("LoadDriver() error = %v, want it to mention line 2", err)
+		}
+	})
+
+	t.Run("missing parent", func(t *testing.T) {
+		input := `[{"name": "bravo", "org_id": "` + org + `", "paths": "alpha.bravo"}]`
+		if _, err := folder.LoadDriver(strings.NewReader(input), folder.LoadOptions{}); !errors.Is(err, folder.ErrFolderNotFound) {
+			t.Errorf("LoadDriver() error = %v, want %v", err, folder.ErrFolderNotFound)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package folder
+
+import (
+	"io"
+	"log/slog"
+)
+
+// Logger used by drivers created without WithLogger and by the helpers in static.go,
+// diagnostics are discarded unless SetDefaultLogger is called
+var defaultLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// Replaces the package wide default logger, nil restores the discarding logger
+func SetDefaultLogger(logger *slog.Logger) {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	defaultLogger = logger
+}
+
+// Configures a driver created by NewDriver
+type Option func(*driver)
+
+// Routes the driver's diagnostic output through logger
+func WithLogger(logger *slog.Logger) Option {
+	return func(d *driver) {
+		if logger != nil {
+			d.logger = logger
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package folder_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_WithLogger(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	initialFolders := []folder.Folder{
+		{Name: "alpha", Paths: "alpha", OrgId: orgId},
+		{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId},
+		{Name: "delta", Paths: "delta", OrgId: orgId},
+	}
+
+	logs := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	driver, err := folder.NewDriver(initialFolders, folder.WithLogger(logger))
+	if err != nil {
+		t.Fatalf("driver failed to initialise - %v", err)
+	}
+
+	stdout := captureStdout(t, func() {
+		if _, err := driver.MoveFolderInOrg(orgId, "bravo", "delta"); err != nil {
+			t.Fatalf("MoveFolderInOrg() received unexpected error: %v", err)
+		}
+		if _, err := driver.GetAllChildFolders(orgId, "bravo"); err != nil {
+			t.Fatalf("GetAllChildFolders() received unexpected error: %v", err)
+		}
+	})
+	if stdout != "" {
+		t.Errorf("driver wrote to stdout: %q", stdout)
+	}
+
+	records := []map[string]interface{}{}
+	dec := json.NewDecoder(logs)
+	for dec.More() {
+		record := map[string]interface{}{}
+		if err := dec.Decode(&record); err != nil {
+			t.Fatalf("log output is not JSON: %v", err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 {
+		t.Fatalf("logged %d records, want 2: %v", len(records), records)
+	}
+	if records[0]["old_path"] != "alpha.bravo" || records[0]["new_path"] != "delta.bravo" || records[0]["org_id"] != orgId.String() {
+		t.Errorf("path update record = %v, want old_path, new_path and org_id attributes", records[0])
+	}
+	if records[1]["name"] != "bravo" {
+		t.Errorf("no children record = %v, want name attribute", records[1])
+	}
+}
+
+func Test_folder_DefaultLoggerDiscards(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+
+	stdout := captureStdout(t, func() {
+		driver, err := folder.NewDriver([]folder.Folder{
+			{Name: "alpha", Paths: "alpha", OrgId: orgId},
+			{Name: "bravo", Paths: "bravo", OrgId: orgId},
+		})
+		if err != nil {
+			t.Fatalf("driver failed to initialise - %v", err)
+		}
+		if _, err := driver.MoveFolder("bravo", "alpha"); err != nil {
+			t.Fatalf("MoveFolder() received unexpected error: %v", err)
+		}
+		folder.GetSampleData()
+	})
+	if stdout != "" {
+		t.Errorf("package wrote to stdout: %q", stdout)
+	}
+}
+
+// returns everything fn writes to os.Stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	out := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- string(b)
+	}()
+
+	fn()
+	w.Close()
+	return <-out
+}
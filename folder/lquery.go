@@ -0,0 +1,282 @@
+package folder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// A parsed PostgreSQL lquery pattern, matched against ltree paths.
+//
+// A pattern is a '.' separated list of items, each item matches one or more labels
+//
+//	*, *{n,m}  any number of labels, or between n and m, *{n}, *{n,} and *{,m} also work
+//	foo        exactly the label foo
+//	foo|bar    either foo or bar
+//	!foo|bar   any label except foo or bar
+//	foo{n,m}   between n and m labels that each match foo, bounds as for *
+//
+// Each label in an item may carry modifiers
+//
+//	foo@       case-insensitive match
+//	foo*       prefix match, matches foobar
+//	foo_bar%   match underscore separated words, matches bar_baz_foo
+type LQuery struct {
+	pattern string
+	items   []lqueryItem
+}
+
+// One '.' separated item of an lquery
+type lqueryItem struct {
+	// star items match any label
+	star    bool
+	negated bool
+	alts    []lqueryLabel
+	// number of labels matched, max is -1 for unbounded
+	min int
+	max int
+}
+
+type lqueryLabel struct {
+	text string
+	// @ modifier
+	caseInsensitive bool
+	// * modifier
+	prefix bool
+	// % modifier
+	words bool
+}
+
+// Parses an lquery pattern
+func ParseLQuery(pattern string) (*LQuery, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("parseLQuery: %w: empty pattern", ErrInvalidPattern)
+	}
+
+	q := &LQuery{pattern: pattern}
+	for i, raw := range strings.Split(pattern, ".") {
+		item, err := parseLQueryItem(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parseLQuery: %w: item %d '%s' of '%s': %v", ErrInvalidPattern, i+1, raw, pattern, err)
+		}
+		q.items = append(q.items, item)
+	}
+	return q, nil
+}
+
+func parseLQueryItem(raw string) (lqueryItem, error) {
+	item := lqueryItem{min: 1, max: 1}
+
+	// split off an optional {n,m} quantifier
+	body := raw
+	if open := strings.IndexByte(raw, '{'); open != -1 {
+		if !strings.HasSuffix(raw, "}") {
+			return item, fmt.Errorf("unterminated quantifier")
+		}
+		min, max, err := parseQuantifier(raw[open+1 : len(raw)-1])
+		if err != nil {
+			return item, err
+		}
+		item.min, item.max = min, max
+		body = raw[:open]
+	} else if body == "*" {
+		item.min, item.max = 0, -1
+	}
+
+	if body == "*" {
+		item.star = true
+		return item, nil
+	}
+
+	if strings.HasPrefix(body, "!") {
+		item.negated = true
+		body = body[1:]
+	}
+	for _, alt := range strings.Split(body, "|") {
+		label, err := parseLQueryLabel(alt)
+		if err != nil {
+			return item, err
+		}
+		item.alts = append(item.alts, label)
+	}
+	return item, nil
+}
+
+// Parses the inside of a {n}, {n,}, {,m} or {n,m} quantifier
+func parseQuantifier(s string) (int, int, error) {
+	lo, hi, hasComma := strings.Cut(s, ",")
+	parse := func(v string, empty int) (int, error) {
+		if v == "" {
+			return empty, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid quantifier bound '%s'", v)
+		}
+		return n, nil
+	}
+
+	if !hasComma {
+		if lo == "" {
+			return 0, 0, fmt.Errorf("empty quantifier")
+		}
+		n, err := parse(lo, 0)
+		return n, n, err
+	}
+	min, err := parse(lo, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	max, err := parse(hi, -1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if max != -1 && min > max {
+		return 0, 0, fmt.Errorf("quantifier lower bound %d exceeds upper bound %d", min, max)
+	}
+	return min, max, nil
+}
+
+func parseLQueryLabel(s string) (lqueryLabel, error) {
+	label := lqueryLabel{}
+
+	// modifiers trail the label in any order
+	end := len(s)
+	for end > 0 && strings.ContainsRune("@*%", rune(s[end-1])) {
+		switch s[end-1] {
+		case '@':
+			label.caseInsensitive = true
+		case '*':
+			label.prefix = true
+		case '%':
+			label.words = true
+		}
+		end--
+	}
+	label.text = s[:end]
+
+	if label.text == "" {
+		return label, fmt.Errorf("empty label")
+	}
+	if strings.ContainsAny(label.text, "!{}@*%") {
+		return label, fmt.Errorf("unexpected character in label '%s'", label.text)
+	}
+	return label, nil
+}
+
+// Pattern the query was parsed from
+func (q *LQuery) String() string {
+	return q.pattern
+}
+
+// Reports whether the ltree path matches the query
+func (q *LQuery) Match(path string) bool {
+	labels := strings.Split(path, ".")
+
+	// memo[i][j] caches whether items[i:] matches labels[j:], 0 unknown, 1 match, 2 no match
+	memo := make([][]int8, len(q.items)+1)
+	for i := range memo {
+		memo[i] = make([]int8, len(labels)+1)
+	}
+
+	var match func(i, j int) bool
+	match = func(i, j int) bool {
+		if i == len(q.items) {
+			return j == len(labels)
+		}
+		if memo[i][j] != 0 {
+			return memo[i][j] == 1
+		}
+
+		item := q.items[i]
+		matched := false
+		// consume k labels with item, stopping at the first label it rejects
+		for k := 0; j+k <= len(labels); k++ {
+			if k >= item.min && match(i+1, j+k) {
+				matched = true
+				break
+			}
+			if k == item.max || j+k == len(labels) || !item.matchLabel(labels[j+k]) {
+				break
+			}
+		}
+
+		memo[i][j] = 2
+		if matched {
+			memo[i][j] = 1
+		}
+		return matched
+	}
+	return match(0, 0)
+}
+
+func (item lqueryItem) matchLabel(label string) bool {
+	if item.star {
+		return true
+	}
+	for _, alt := range item.alts {
+		if alt.match(label) {
+			return !item.negated
+		}
+	}
+	return item.negated
+}
+
+func (l lqueryLabel) match(label string) bool {
+	if !l.words {
+		return l.matchWord(l.text, label)
+	}
+
+	// every word of the pattern must match some word of the label
+	labelWords := strings.Split(label, "_")
+	for _, patternWord := range strings.Split(l.text, "_") {
+		found := false
+		for _, labelWord := range labelWords {
+			if l.matchWord(patternWord, labelWord) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (l lqueryLabel) matchWord(pattern string, word string) bool {
+	if l.prefix {
+		if len(word) < len(pattern) {
+			return false
+		}
+		word = word[:len(pattern)]
+	}
+	if l.caseInsensitive {
+		return strings.EqualFold(pattern, word)
+	}
+	return pattern == word
+}
+
+// Returns every folder in orgID whose path matches the lquery pattern
+func (d *driver) MatchPaths(orgID uuid.UUID, pattern string) ([]Folder, error) {
+	if orgID == uuid.Nil {
+		return nil, invalidOrgIDError("MatchPaths", orgID)
+	}
+	q, err := ParseLQuery(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("MatchPaths: %w", err)
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	res := []Folder{}
+	for _, folder := range d.orgIdIndex[orgID] {
+		if q.Match(folder.Paths) {
+			res = append(res, *folder)
+		}
+	}
+	return res, nil
+}
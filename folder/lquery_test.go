@@ -0,0 +1,181 @@
+package folder_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_LQuery_Match(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		// exact labels
+		{pattern: "alpha", path: "alpha", want: true},
+		{pattern: "alpha", path: "alpha.bravo", want: false},
+		{pattern: "alpha.bravo", path: "alpha.bravo", want: true},
+		{pattern: "alpha", path: "Alpha", want: false},
+
+		// stars and quantifiers
+		{pattern: "*.charlie.*", path: "alpha.bravo.charlie.delta", want: true},
+		{pattern: "*.charlie.*", path: "charlie", want: true},
+		{pattern: "*.charlie", path: "alpha.charlie.delta", want: false},
+		{pattern: "alpha.*{1}", path: "alpha.bravo", want: true},
+		{pattern: "alpha.*{1}", path: "alpha.bravo.charlie", want: false},
+		{pattern: "alpha.*{2,}", path: "alpha.bravo", want: false},
+		{pattern: "alpha.*{2,}", path: "alpha.bravo.charlie.delta", want: true},
+		{pattern: "alpha.*{,1}.charlie", path: "alpha.charlie", want: true},
+		{pattern: "alpha.*{,1}.charlie", path: "alpha.bravo.bravo.charlie", want: false},
+		{pattern: "*{1,2}", path: "alpha.bravo", want: true},
+		{pattern: "*{1,2}", path: "alpha.bravo.charlie", want: false},
+
+		// alternatives and negation
+		{pattern: "alpha.bravo|delta", path: "alpha.delta", want: true},
+		{pattern: "alpha.bravo|delta", path: "alpha.echo", want: false},
+		{pattern: "alpha.!bravo|delta", path: "alpha.echo", want: true},
+		{pattern: "alpha.!bravo|delta", path: "alpha.bravo", want: false},
+		{pattern: "alpha.!bravo{2}", path: "alpha.charlie.delta", want: true},
+		{pattern: "alpha.!bravo{2}", path: "alpha.charlie.bravo", want: false},
+		{pattern: "bravo{2,}.charlie", path: "bravo.bravo.charlie", want: true},
+
+		// modifiers
+		{pattern: "alpha@", path: "ALPHA", want: true},
+		{pattern: "al*", path: "alpha", want: true},
+		{pattern: "al*", path: "beta", want: false},
+		{pattern: "AL*@", path: "alpha", want: true},
+		{pattern: "foo_bar%", path: "bar_baz_foo", want: true},
+		{pattern: "foo_bar%", path: "foo_baz", want: false},
+		{pattern: "fo*%", path: "baz_foo", want: true},
+		{pattern: "FOO%@", path: "bar_foo", want: true},
+
+		// combined, as in the PostgreSQL documentation
+		{pattern: "Top.*{0,2}.sport*@.!football|tennis{1,}.Russ*|Spain", path: "Top.Sport.Hockey.Russia", want: true},
+		{pattern: "Top.*{0,2}.sport*@.!football|tennis{1,}.Russ*|Spain", path: "Top.Games.Sports.Curling.Spain", want: true},
+		{pattern: "Top.*{0,2}.sport*@.!football|tennis{1,}.Russ*|Spain", path: "Top.Sport.tennis.Russia", want: false},
+		{pattern: "Top.*{0,2}.sport*@.!football|tennis{1,}.Russ*|Spain", path: "Top.Sport.Russia", want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.pattern+" ~ "+tt.path, func(t *testing.T) {
+			q, err := folder.ParseLQuery(tt.pattern)
+			if err != nil {
+				t.Fatalf("ParseLQuery(%q) received unexpected error: %v", tt.pattern, err)
+			}
+			if got := q.Match(tt.path); got != tt.want {
+				t.Errorf("ParseLQuery(%q).Match(%q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_folder_ParseLQuery_Errors(t *testing.T) {
+	patterns := []string{
+		"",
+		"alpha..bravo",
+		"alpha.",
+		"alpha|",
+		"!",
+		"*{2,1}",
+		"*{a}",
+		"*{}",
+		"*{1",
+		"al!pha",
+		"@",
+	}
+
+	for _, pattern := range patterns {
+		pattern := pattern
+
+		t.Run(pattern, func(t *testing.T) {
+			if _, err := folder.ParseLQuery(pattern); !errors.Is(err, folder.ErrInvalidPattern) {
+				t.Errorf("ParseLQuery(%q) error = %v, want errors.Is %v", pattern, err, folder.ErrInvalidPattern)
+			}
+		})
+	}
+}
+
+func Test_folder_MatchPaths(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name         string
+		orgID        uuid.UUID
+		pattern      string
+		want         []folder.Folder
+		wantRunError bool
+	}{
+		{
+			name:    "every folder under alpha",
+			orgID:   orgId1,
+			pattern: "alpha.*{1,}",
+			want: []folder.Folder{
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+			},
+		},
+		{
+			name:    "only matches within the org",
+			orgID:   orgId2,
+			pattern: "*.charlie",
+			want: []folder.Folder{
+				{Name: "charlie", Paths: "foxtrot.charlie", OrgId: orgId2},
+			},
+		},
+		{
+			name:    "no matches",
+			orgID:   orgId1,
+			pattern: "golf.*",
+			want:    []folder.Folder{},
+		},
+		{
+			name:         "invalid pattern",
+			orgID:        orgId1,
+			pattern:      "alpha..bravo",
+			wantRunError: true,
+		},
+		{
+			name:         "invalid orgID",
+			orgID:        uuid.Nil,
+			pattern:      "*",
+			wantRunError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := folder.NewDriver([]folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+				{Name: "charlie", Paths: "foxtrot.charlie", OrgId: orgId2},
+			})
+			if err != nil {
+				t.Fatalf("driver failed to initialise - %v", err)
+			}
+
+			got, err := driver.MatchPaths(tt.orgID, tt.pattern)
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("MatchPaths() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if tt.wantRunError {
+				return
+			}
+			if !compareFolders(got, tt.want) {
+				t.Errorf("MatchPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,34 +2,67 @@ package folder
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/gofrs/uuid"
 )
 
-// Assumption:
-// There can only be one orgId that contains
-// a specific pair of folders with the names "name1" and "name2".
+// Moves folder name under dst, inferring the org from the folder names.
+// If both names exist together in more than one org the move is ambiguous
+// and an error listing the candidate orgs is returned, use MoveFolderInOrg instead.
 func (d *driver) MoveFolder(name string, dst string) ([]Folder, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
 	// check if src and dst are the same
 	if name == dst {
-		return nil, fmt.Errorf("moveFolder: cannot move folder to itself")
+		return nil, &FolderError{Err: ErrCycle, Name: name, msg: "moveFolder: cannot move folder to itself"}
 	}
 
 	// get matching orgId
 	matchingOrgId, err := d.getMatchingOrgId(name, dst)
 	if err != nil {
-		return nil, fmt.Errorf("moveFolder: %v", err)
+		return nil, fmt.Errorf("moveFolder: %w", err)
+	}
+
+	return d.moveFolder(matchingOrgId, name, dst)
+}
+
+// Moves folder name under dst within orgID.
+func (d *driver) MoveFolderInOrg(orgID uuid.UUID, name string, dst string) ([]Folder, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if orgID == uuid.Nil {
+		return nil, invalidOrgIDError("moveFolder", orgID)
 	}
 
-	// get srcFolder and dstFolder with matchingOrgId
-	srcFolder, dstFolder, err := d.getFolders(matchingOrgId, name, dst)
+	// check if src and dst are the same
+	if name == dst {
+		return nil, &FolderError{Err: ErrCycle, Name: name, msg: "moveFolder: cannot move folder to itself"}
+	}
+
+	return d.moveFolder(orgID, name, dst)
+}
+
+// Moves folder name under dst within orgId, caller must hold the write lock
+func (d *driver) moveFolder(orgId uuid.UUID, name string, dst string) ([]Folder, error) {
+	// get srcFolder and dstFolder with orgId
+	srcFolder, dstFolder, err := d.getFolders(orgId, name, dst)
 	if err != nil {
-		return nil, fmt.Errorf("moveFolder: %v", err)
+		return nil, fmt.Errorf("moveFolder: %w", err)
 	}
 
 	// check for cycles, dst cannot be a descendent of src
 	if d.isDescendent(dstFolder, srcFolder) {
-		return nil, fmt.Errorf("moveFolder: dstFolder '%s' cannot be a descendent of scrFolder '%s'", dst, name)
+		return nil, &FolderError{
+			Err:   ErrCycle,
+			Name:  name,
+			OrgID: orgId,
+			Path:  dstFolder.Paths,
+			msg:   fmt.Sprintf("moveFolder: dstFolder '%s' cannot be a descendent of scrFolder '%s'", dst, name),
+		}
 	}
 
 	// if srcFolder is already in the dstFolder, do nothing
@@ -41,7 +74,7 @@ func (d *driver) MoveFolder(name string, dst string) ([]Folder, error) {
 	if srcFolder.Parent != nil {
 		err := d.removeChild(srcFolder.Parent, srcFolder)
 		if err != nil {
-			return nil, fmt.Errorf("moveFolder: error removing source folder from its current parent: %v", err)
+			return nil, fmt.Errorf("moveFolder: error removing source folder from its current parent: %w", err)
 		}
 	}
 
@@ -58,6 +91,7 @@ func (d *driver) MoveFolder(name string, dst string) ([]Folder, error) {
 }
 
 // Function to get matchingOrgId given srcName and dstName
+// returns an error if no org or more than one org contains both folders
 func (d *driver) getMatchingOrgId(name string, dst string) (uuid.UUID, error) {
 	srcNameFolders := d.nameIndex[name]
 	destNameFolders := d.nameIndex[dst]
@@ -66,37 +100,50 @@ func (d *driver) getMatchingOrgId(name string, dst string) (uuid.UUID, error) {
 	for _, folder := range srcNameFolders {
 		srcNameOrgIds[folder.OrgId] = true
 	}
-	var matchingOrgId uuid.UUID
-	orgIdMatched := false
+	matchingOrgIds := []uuid.UUID{}
 	for _, folder := range destNameFolders {
 		_, found := srcNameOrgIds[folder.OrgId]
 		if found {
-			matchingOrgId = folder.OrgId
-			orgIdMatched = true
+			matchingOrgIds = append(matchingOrgIds, folder.OrgId)
 		}
 	}
-	if !orgIdMatched {
-		return uuid.Nil, fmt.Errorf("getMatchingOrgId: No matching orgId found between '%s' and '%s'", name, dst)
+	if len(srcNameFolders) == 0 {
+		return uuid.Nil, &FolderError{Err: ErrFolderNotFound, Name: name, msg: fmt.Sprintf("getMatchingOrgId: Source folder '%s' not found", name)}
+	}
+	if len(destNameFolders) == 0 {
+		return uuid.Nil, &FolderError{Err: ErrFolderNotFound, Name: dst, msg: fmt.Sprintf("getMatchingOrgId: Destination folder '%s' not found", dst)}
+	}
+	if len(matchingOrgIds) == 0 {
+		return uuid.Nil, &FolderError{
+			Err:  ErrWrongOrg,
+			Name: name,
+			msg:  fmt.Sprintf("getMatchingOrgId: No matching orgId found between '%s' and '%s'", name, dst),
+		}
 	}
-	return matchingOrgId, nil
+	if len(matchingOrgIds) > 1 {
+		candidates := make([]string, 0, len(matchingOrgIds))
+		for _, orgId := range matchingOrgIds {
+			candidates = append(candidates, orgId.String())
+		}
+		sort.Strings(candidates)
+		return uuid.Nil, &FolderError{
+			Err:  ErrAmbiguousName,
+			Name: name,
+			msg:  fmt.Sprintf("getMatchingOrgId: '%s' and '%s' are ambiguous, both exist in orgs [%s]", name, dst, strings.Join(candidates, ", ")),
+		}
+	}
+	return matchingOrgIds[0], nil
 }
 
 // Get srcFolder and dstFolder given orgId and folderNames
 func (d *driver) getFolders(orgId uuid.UUID, name string, dst string) (*Folder, *Folder, error) {
-	var srcFolder *Folder
-	var dstFolder *Folder
-	for _, folder := range d.orgIdIndex[orgId] {
-		if folder.Name == name {
-			srcFolder = folder
-		} else if folder.Name == dst {
-			dstFolder = folder
-		}
-	}
-	if srcFolder == nil {
-		return nil, nil, fmt.Errorf("getFolders: Source folder '%s' not found", name)
+	srcFolder, err := d.getFolderInOrg(orgId, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getFolders: Source %w", err)
 	}
-	if dstFolder == nil {
-		return nil, nil, fmt.Errorf("getFolders: Destination folder '%s' not found", dst)
+	dstFolder, err := d.getFolderInOrg(orgId, dst)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getFolders: Destination %w", err)
 	}
 	return srcFolder, dstFolder, nil
 }
@@ -105,11 +152,12 @@ func (d *driver) getFolders(orgId uuid.UUID, name string, dst string) (*Folder,
 func (d *driver) updatePaths(currFolder *Folder, newPath string) {
 	oldPaths := currFolder.Paths
 	currFolder.Paths = newPath
-	fmt.Printf("old currFolder.Paths = %s, new = %s\n", oldPaths, newPath)
-	delete(d.pathIndex, oldPaths)
+	d.logger.Debug("updatePaths: folder path updated",
+		"name", currFolder.Name, "org_id", currFolder.OrgId, "old_path", oldPaths, "new_path", newPath)
+	delete(d.pathIndex, pathKey{currFolder.OrgId, oldPaths})
 
 	// remove oldPath index, create new pathIndex
-	d.pathIndex[newPath] = currFolder
+	d.pathIndex[pathKey{currFolder.OrgId, newPath}] = currFolder
 
 	for _, child := range currFolder.Children {
 		c := child
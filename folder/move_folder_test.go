@@ -191,6 +191,21 @@ func Test_folder_MoveFolder(t *testing.T) {
 			want:         nil,
 			wantRunError: true,
 		},
+		{
+			name: "error moving folders whose names exist together in more than one org",
+			initialFolders: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "delta", Paths: "delta", OrgId: orgId1},
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+				{Name: "bravo", Paths: "foxtrot.bravo", OrgId: orgId2},
+				{Name: "delta", Paths: "foxtrot.delta", OrgId: orgId2},
+			},
+			source:       "bravo",
+			destination:  "delta",
+			want:         nil,
+			wantRunError: true,
+		},
 		{
 			name: "invald source folder name",
 			initialFolders: []folder.Folder{
@@ -258,3 +273,104 @@ func Test_folder_MoveFolder(t *testing.T) {
 		})
 	}
 }
+
+func Test_folder_MoveFolderInOrg(t *testing.T) {
+
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	// bravo and delta exist together in both orgs, which MoveFolder cannot resolve
+	initialFolders := []folder.Folder{
+		{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+		{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+		{Name: "delta", Paths: "delta", OrgId: orgId1},
+		{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+		{Name: "bravo", Paths: "foxtrot.bravo", OrgId: orgId2},
+		{Name: "delta", Paths: "foxtrot.delta", OrgId: orgId2},
+	}
+
+	tests := []struct {
+		name         string
+		orgID        uuid.UUID
+		source       string
+		destination  string
+		want         []folder.Folder
+		wantRunError bool
+	}{
+		{
+			name:        "move folder in first org",
+			orgID:       orgId1,
+			source:      "bravo",
+			destination: "delta",
+			want: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "delta.bravo", OrgId: orgId1},
+				{Name: "delta", Paths: "delta", OrgId: orgId1},
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+				{Name: "bravo", Paths: "foxtrot.bravo", OrgId: orgId2},
+				{Name: "delta", Paths: "foxtrot.delta", OrgId: orgId2},
+			},
+		},
+		{
+			name:        "move folder in second org",
+			orgID:       orgId2,
+			source:      "bravo",
+			destination: "delta",
+			want: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "delta", Paths: "delta", OrgId: orgId1},
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+				{Name: "bravo", Paths: "foxtrot.delta.bravo", OrgId: orgId2},
+				{Name: "delta", Paths: "foxtrot.delta", OrgId: orgId2},
+			},
+		},
+
+		// edge cases an error checking
+		{
+			name:         "error moving to a folder that only exists in another org",
+			orgID:        orgId1,
+			source:       "bravo",
+			destination:  "foxtrot",
+			wantRunError: true,
+		},
+		{
+			name:         "error moving a folder to itself",
+			orgID:        orgId1,
+			source:       "bravo",
+			destination:  "bravo",
+			wantRunError: true,
+		},
+		{
+			name:         "error moving with invalid orgID",
+			orgID:        uuid.Nil,
+			source:       "bravo",
+			destination:  "delta",
+			wantRunError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := folder.NewDriver(initialFolders)
+			if err != nil {
+				t.Fatalf("driver failed to initialise - %v", err)
+			}
+
+			got, err := driver.MoveFolderInOrg(tt.orgID, tt.source, tt.destination)
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("MoveFolderInOrg() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if tt.wantRunError {
+				return
+			}
+
+			if !compareFolders(got, tt.want) {
+				t.Errorf("MoveFolderInOrg() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
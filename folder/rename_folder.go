@@ -0,0 +1,58 @@
+package folder
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+)
+
+// Renames the folder oldName to newName within orgID.
+// Returns the renamed folder and all of its descendents with their updated paths.
+func (d *driver) RenameFolder(orgID uuid.UUID, oldName string, newName string) ([]Folder, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if orgID == uuid.Nil {
+		return nil, invalidOrgIDError("renameFolder", orgID)
+	}
+	if err := validateName(newName); err != nil {
+		return nil, fmt.Errorf("renameFolder: %w", err)
+	}
+
+	target, err := d.getFolderInOrg(orgID, oldName)
+	if err != nil {
+		return nil, fmt.Errorf("renameFolder: %w", err)
+	}
+
+	// renaming to the same name is a no-op
+	if oldName == newName {
+		return d.collectSubtree(target), nil
+	}
+
+	if _, err := d.getFolderInOrg(orgID, newName); err == nil {
+		return nil, duplicateNameError("renameFolder", newName, orgID)
+	}
+
+	d.nameIndex[oldName] = removeFolder(d.nameIndex[oldName], target)
+	if len(d.nameIndex[oldName]) == 0 {
+		delete(d.nameIndex, oldName)
+	}
+	d.nameIndex[newName] = append(d.nameIndex[newName], target)
+	target.Name = newName
+
+	newPath := newName
+	if target.Parent != nil {
+		newPath = fmt.Sprintf("%s.%s", target.Parent.Paths, newName)
+	}
+
+	// update all the paths and update pathIndex along the way
+	d.updatePaths(target, newPath)
+
+	return d.collectSubtree(target), nil
+}
+
+// Returns root and all of its descendents, parents before children
+func (d *driver) collectSubtree(root *Folder) []Folder {
+	descendents, _ := collectAllDescendents(root)
+	return append([]Folder{*root}, descendents...)
+}
@@ -0,0 +1,150 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_RenameFolder(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name         string
+		orgID        uuid.UUID
+		oldName      string
+		newName      string
+		want         []folder.Folder
+		wantRunError bool
+	}{
+		// Functionalities
+		{
+			name:    "rename leaf folder",
+			orgID:   orgId1,
+			oldName: "charlie",
+			newName: "hotel",
+			want: []folder.Folder{
+				{Name: "hotel", Paths: "alpha.bravo.hotel", OrgId: orgId1},
+			},
+		},
+		{
+			name:    "rename folder with descendents",
+			orgID:   orgId1,
+			oldName: "bravo",
+			newName: "hotel",
+			want: []folder.Folder{
+				{Name: "hotel", Paths: "alpha.hotel", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.hotel.charlie", OrgId: orgId1},
+			},
+		},
+		{
+			name:    "rename root folder",
+			orgID:   orgId1,
+			oldName: "alpha",
+			newName: "hotel",
+			want: []folder.Folder{
+				{Name: "hotel", Paths: "hotel", OrgId: orgId1},
+				{Name: "bravo", Paths: "hotel.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "hotel.bravo.charlie", OrgId: orgId1},
+				{Name: "delta", Paths: "hotel.delta", OrgId: orgId1},
+			},
+		},
+		{
+			name:    "rename to a name used in a different org",
+			orgID:   orgId1,
+			oldName: "delta",
+			newName: "foxtrot",
+			want: []folder.Folder{
+				{Name: "foxtrot", Paths: "alpha.foxtrot", OrgId: orgId1},
+			},
+		},
+		{
+			name:    "rename to the same name",
+			orgID:   orgId1,
+			oldName: "delta",
+			newName: "delta",
+			want: []folder.Folder{
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+			},
+		},
+
+		// edge cases and error checking
+		{
+			name:         "error renaming to a name that already exists in the org",
+			orgID:        orgId1,
+			oldName:      "bravo",
+			newName:      "delta",
+			wantRunError: true,
+		},
+		{
+			name:         "error renaming to a name containing '.'",
+			orgID:        orgId1,
+			oldName:      "bravo",
+			newName:      "hotel.india",
+			wantRunError: true,
+		},
+		{
+			name:         "error renaming to an empty name",
+			orgID:        orgId1,
+			oldName:      "bravo",
+			newName:      "",
+			wantRunError: true,
+		},
+		{
+			name:         "error renaming folder in a different org",
+			orgID:        orgId1,
+			oldName:      "foxtrot",
+			newName:      "hotel",
+			wantRunError: true,
+		},
+		{
+			name:         "error renaming non-existent folder",
+			orgID:        orgId1,
+			oldName:      "invalid_folder",
+			newName:      "hotel",
+			wantRunError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := folder.NewDriver([]folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+			})
+			if err != nil {
+				t.Fatalf("driver failed to initialise - %v", err)
+			}
+
+			got, err := driver.RenameFolder(tt.orgID, tt.oldName, tt.newName)
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("RenameFolder() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if tt.wantRunError {
+				return
+			}
+
+			if !compareFolders(got, tt.want) {
+				t.Errorf("RenameFolder() = %v, want %v", got, tt.want)
+			}
+
+			// the folder is only reachable through its new name
+			if _, err := driver.GetAllChildFolders(tt.orgID, tt.newName); err != nil {
+				t.Errorf("GetAllChildFolders() on renamed folder received unexpected error: %v", err)
+			}
+			if tt.oldName != tt.newName {
+				if _, err := driver.GetAllChildFolders(tt.orgID, tt.oldName); err == nil {
+					t.Errorf("GetAllChildFolders() on old name expected an error but got none")
+				}
+			}
+		})
+	}
+}
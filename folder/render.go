@@ -0,0 +1,94 @@
+package folder
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Options shared by the tree renderers
+type RenderOptions struct {
+	// Highlight lists folder names to draw in a highlight colour, e.g. a moved subtree
+	Highlight []string
+}
+
+func (o RenderOptions) highlighted() map[string]bool {
+	set := make(map[string]bool, len(o.Highlight))
+	for _, name := range o.Highlight {
+		set[name] = true
+	}
+	return set
+}
+
+const highlightFill = "#ffd966"
+
+// Writes nodes as a Graphviz DOT digraph, node IDs are the folder paths
+func WriteDOT(w io.Writer, nodes []TreeNode, opts RenderOptions) error {
+	bw := bufio.NewWriter(w)
+	highlight := opts.highlighted()
+
+	fmt.Fprintln(bw, "digraph folders {")
+	fmt.Fprintln(bw, "\tnode [shape=folder];")
+	var visit func(node TreeNode)
+	visit = func(node TreeNode) {
+		attrs := fmt.Sprintf("label=%s", dotQuote(node.Name))
+		if highlight[node.Name] {
+			attrs += fmt.Sprintf(", style=filled, fillcolor=%s", dotQuote(highlightFill))
+		}
+		fmt.Fprintf(bw, "\t%s [%s];\n", dotQuote(node.Path), attrs)
+		for _, child := range node.Children {
+			fmt.Fprintf(bw, "\t%s -> %s;\n", dotQuote(node.Path), dotQuote(child.Path))
+			visit(child)
+		}
+	}
+	for _, node := range nodes {
+		visit(node)
+	}
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+// Writes nodes as a top down Mermaid flowchart. Folder names may hold characters
+// Mermaid does not allow in IDs, so nodes are numbered in pre-order instead.
+func WriteMermaid(w io.Writer, nodes []TreeNode, opts RenderOptions) error {
+	bw := bufio.NewWriter(w)
+	highlight := opts.highlighted()
+
+	fmt.Fprintln(bw, "flowchart TD")
+	highlightedIDs := []string{}
+	next := 0
+	var visit func(node TreeNode, parentID string)
+	visit = func(node TreeNode, parentID string) {
+		id := fmt.Sprintf("n%d", next)
+		next++
+		fmt.Fprintf(bw, "    %s[\"%s\"]\n", id, mermaidEscape(node.Name))
+		if parentID != "" {
+			fmt.Fprintf(bw, "    %s --> %s\n", parentID, id)
+		}
+		if highlight[node.Name] {
+			highlightedIDs = append(highlightedIDs, id)
+		}
+		for _, child := range node.Children {
+			visit(child, id)
+		}
+	}
+	for _, node := range nodes {
+		visit(node, "")
+	}
+	if len(highlightedIDs) > 0 {
+		fmt.Fprintf(bw, "    classDef highlight fill:%s\n", highlightFill)
+		fmt.Fprintf(bw, "    class %s highlight\n", strings.Join(highlightedIDs, ","))
+	}
+
+	return bw.Flush()
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;").Replace(s)
+}
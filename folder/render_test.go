@@ -0,0 +1,127 @@
+package folder_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func renderTestNodes(orgId uuid.UUID) []folder.TreeNode {
+	return []folder.TreeNode{
+		{Name: "alpha", Path: "alpha", OrgId: orgId, Children: []folder.TreeNode{
+			{Name: "bravo", Path: "alpha.bravo", OrgId: orgId, Children: []folder.TreeNode{
+				{Name: `char"lie`, Path: `alpha.bravo.char"lie`, OrgId: orgId},
+			}},
+			{Name: "delta", Path: "alpha.delta", OrgId: orgId},
+		}},
+		{Name: "echo", Path: "echo", OrgId: orgId},
+	}
+}
+
+func Test_folder_WriteDOT(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name string
+		opts folder.RenderOptions
+		want string
+	}{
+		{
+			name: "without highlighting",
+			want: "digraph folders {\n" +
+				"\tnode [shape=folder];\n" +
+				"\t\"alpha\" [label=\"alpha\"];\n" +
+				"\t\"alpha\" -> \"alpha.bravo\";\n" +
+				"\t\"alpha.bravo\" [label=\"bravo\"];\n" +
+				"\t\"alpha.bravo\" -> \"alpha.bravo.char\\\"lie\";\n" +
+				"\t\"alpha.bravo.char\\\"lie\" [label=\"char\\\"lie\"];\n" +
+				"\t\"alpha\" -> \"alpha.delta\";\n" +
+				"\t\"alpha.delta\" [label=\"delta\"];\n" +
+				"\t\"echo\" [label=\"echo\"];\n" +
+				"}\n",
+		},
+		{
+			name: "with highlighted subtree",
+			opts: folder.RenderOptions{Highlight: []string{"bravo", `char"lie`}},
+			want: "digraph folders {\n" +
+				"\tnode [shape=folder];\n" +
+				"\t\"alpha\" [label=\"alpha\"];\n" +
+				"\t\"alpha\" -> \"alpha.bravo\";\n" +
+				"\t\"alpha.bravo\" [label=\"bravo\", style=filled, fillcolor=\"#ffd966\"];\n" +
+				"\t\"alpha.bravo\" -> \"alpha.bravo.char\\\"lie\";\n" +
+				"\t\"alpha.bravo.char\\\"lie\" [label=\"char\\\"lie\", style=filled, fillcolor=\"#ffd966\"];\n" +
+				"\t\"alpha\" -> \"alpha.delta\";\n" +
+				"\t\"alpha.delta\" [label=\"delta\"];\n" +
+				"\t\"echo\" [label=\"echo\"];\n" +
+				"}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := folder.WriteDOT(&buf, renderTestNodes(orgId), tt.opts); err != nil {
+				t.Fatalf("WriteDOT() received unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("WriteDOT() wrote\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_folder_WriteMermaid(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name string
+		opts folder.RenderOptions
+		want string
+	}{
+		{
+			name: "without highlighting",
+			want: "flowchart TD\n" +
+				"    n0[\"alpha\"]\n" +
+				"    n1[\"bravo\"]\n" +
+				"    n0 --> n1\n" +
+				"    n2[\"char#quot;lie\"]\n" +
+				"    n1 --> n2\n" +
+				"    n3[\"delta\"]\n" +
+				"    n0 --> n3\n" +
+				"    n4[\"echo\"]\n",
+		},
+		{
+			name: "with highlighted folders",
+			opts: folder.RenderOptions{Highlight: []string{"delta", "echo", "missing"}},
+			want: "flowchart TD\n" +
+				"    n0[\"alpha\"]\n" +
+				"    n1[\"bravo\"]\n" +
+				"    n0 --> n1\n" +
+				"    n2[\"char#quot;lie\"]\n" +
+				"    n1 --> n2\n" +
+				"    n3[\"delta\"]\n" +
+				"    n0 --> n3\n" +
+				"    n4[\"echo\"]\n" +
+				"    classDef highlight fill:#ffd966\n" +
+				"    class n3,n4 highlight\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := folder.WriteMermaid(&buf, renderTestNodes(orgId), tt.opts); err != nil {
+				t.Fatalf("WriteMermaid() received unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("WriteMermaid() wrote\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
@@ -3,8 +3,6 @@ package folder
 import (
 	"encoding/json"
 	"fmt"
-	"io"
-	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -40,6 +38,7 @@ type Folder struct {
 	Children []*Folder `json:"-"`
 }
 
+// GenerateData produces different folders on every run, use Generate for reproducible data
 func GenerateData() []Folder {
 	// generates rng names from codename library, error is ignored
 	rng, _ := codename.DefaultRNG()
@@ -128,49 +127,65 @@ func PrettyPrint(b interface{}) {
 }
 
 // Since go doesn't support default parameters, we will create a wrapper function
+// fileName is relative to this package's directory and read in the format its extension picks.
+// Panics on error, use ReadSampleDataFrom to handle it.
 func GetSampleDataFrom(fileName string) []Folder {
-	_, filename, _, _ := runtime.Caller(0)
-	fmt.Println(filename)
-	basePath := filepath.Dir(filename)
-	filePath := filepath.Join(basePath, fileName)
-
-	fmt.Println(filePath)
-
-	file, err := os.Open(filePath)
+	folders, err := ReadSampleDataFrom(samplePath(fileName))
 	if err != nil {
 		panic(err)
 	}
-	defer file.Close()
+	return folders
+}
 
-	jsonByte, err := io.ReadAll(file)
-	if err != nil {
-		panic(err)
-	}
+// Reads the folders at path through a FileStore, unlike FileStore.Load a missing file is an error
+func ReadSampleDataFrom(path string) ([]Folder, error) {
+	defaultLogger.Debug("ReadSampleDataFrom: reading sample data", "path", path)
 
-	folders := []Folder{}
-	err = json.Unmarshal(jsonByte, &folders)
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("readSampleData: %w", err)
+	}
+	folders, err := NewFileStore(path).Load()
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("readSampleData: %w", err)
 	}
-
-	return folders
+	return folders, nil
 }
 
 func GetSampleData() []Folder {
 	return GetSampleDataFrom("sample.json")
 }
 
+// Writes data to sample.json in this package's directory.
+// Panics on error, use WriteSampleDataTo to handle it or to write elsewhere.
 func WriteSampleData(data interface{}) {
-	b := MarshalJson(data)
-	_, filename, _, _ := runtime.Caller(0)
-	fmt.Println(filename)
-	basePath := filepath.Dir(filename)
-	filePath := filepath.Join(basePath, "sample.json")
+	if err := WriteSampleDataTo(samplePath("sample.json"), data); err != nil {
+		panic(err)
+	}
+}
 
-	fmt.Println(filePath)
+// Atomically writes data to path. Folders are saved through a FileStore in the format
+// the extension picks, anything else is written as indented JSON.
+func WriteSampleDataTo(path string, data interface{}) error {
+	defaultLogger.Debug("WriteSampleDataTo: writing sample data", "path", path)
 
-	err := os.WriteFile(filePath, b, fs.ModeType)
+	if folders, ok := data.([]Folder); ok {
+		if err := NewFileStore(path).Save(folders); err != nil {
+			return fmt.Errorf("writeSampleData: %w", err)
+		}
+		return nil
+	}
+	b, err := json.MarshalIndent(data, "", "\t")
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("writeSampleData: %w", err)
+	}
+	if err := writeFileAtomic(path, b); err != nil {
+		return fmt.Errorf("writeSampleData: %w", err)
 	}
+	return nil
+}
+
+// Path of fileName in this package's directory
+func samplePath(fileName string) string {
+	_, filename, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(filename), fileName)
 }
@@ -0,0 +1,73 @@
+package folder_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_SampleData(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	folders := []folder.Folder{
+		{Name: "alpha", Paths: "alpha", OrgId: orgId},
+		{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId},
+	}
+
+	tests := []struct {
+		name     string
+		fileName string
+	}{
+		{name: "json", fileName: "sample.json"},
+		{name: "csv", fileName: "sample.csv"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name+" round trip outside the package directory", func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.fileName)
+			if err := folder.WriteSampleDataTo(path, folders); err != nil {
+				t.Fatalf("WriteSampleDataTo() received unexpected error: %v", err)
+			}
+			got, err := folder.ReadSampleDataFrom(path)
+			if err != nil {
+				t.Fatalf("ReadSampleDataFrom() received unexpected error: %v", err)
+			}
+			if !compareFolders(got, folders) {
+				t.Errorf("ReadSampleDataFrom() = %v, want %v", got, folders)
+			}
+		})
+	}
+
+	t.Run("missing file returns an error", func(t *testing.T) {
+		_, err := folder.ReadSampleDataFrom(filepath.Join(t.TempDir(), "missing.json"))
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("ReadSampleDataFrom() error = %v, want %v", err, os.ErrNotExist)
+		}
+	})
+
+	t.Run("unwritable path returns an error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing", "sample.json")
+		if err := folder.WriteSampleDataTo(path, folders); err == nil {
+			t.Errorf("WriteSampleDataTo() expected an error but got none")
+		}
+	})
+
+	t.Run("other data is written as JSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tree.json")
+		if err := folder.WriteSampleDataTo(path, map[string]int{"alpha": 1}); err != nil {
+			t.Fatalf("WriteSampleDataTo() received unexpected error: %v", err)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != "{\n\t\"alpha\": 1\n}" {
+			t.Errorf("WriteSampleDataTo() wrote %q", b)
+		}
+	})
+}
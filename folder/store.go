@@ -0,0 +1,310 @@
+package folder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+// Loads and saves folders as a JSON file in the same format as sample.json,
+// as JSON Lines when the path ends in .jsonl, or as CSV with name, org_id and
+// paths columns when the path ends in .csv
+type FileStore struct {
+	path string
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Path of the backing file
+func (s *FileStore) Path() string {
+	return s.path
+}
+
+// Reads all folders from the backing file, a missing file holds no folders
+func (s *FileStore) Load() ([]Folder, error) {
+	folders := []Folder{}
+	err := s.Stream(func(f Folder) error {
+		folders = append(folders, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return folders, nil
+}
+
+// Passes every folder in the backing file to fn without reading the whole file first,
+// a missing file holds no folders. Stops at the first error, including one returned by fn.
+func (s *FileStore) Stream(fn func(Folder) error) error {
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("fileStore: %w", err)
+	}
+	defer file.Close()
+
+	if err := streamFoldersFrom(s.path, file, fn); err != nil {
+		return fmt.Errorf("fileStore: reading '%s': %w", s.path, err)
+	}
+	return nil
+}
+
+// Streams the backing file straight into a new driver, a missing file gives an empty driver
+func (s *FileStore) LoadDriver(opts ...Option) (IDriver, error) {
+	builder := NewDriverBuilder(opts...)
+	if err := s.Stream(builder.Add); err != nil {
+		return nil, err
+	}
+	return builder.Build()
+}
+
+// Atomically replaces the backing file with folders
+func (s *FileStore) Save(folders []Folder) error {
+	b, err := encodeFolders(s.path, folders)
+	if err != nil {
+		return fmt.Errorf("fileStore: %w", err)
+	}
+	if err := writeFileAtomic(s.path, b); err != nil {
+		return fmt.Errorf("fileStore: %w", err)
+	}
+	return nil
+}
+
+// Decodes folders in the format picked by the extension of path, CSV for .csv and JSON otherwise,
+// where a JSON array and JSON Lines are both accepted
+func decodeFolders(path string, r io.Reader) ([]Folder, error) {
+	folders := []Folder{}
+	err := streamFoldersFrom(path, r, func(f Folder) error {
+		folders = append(folders, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return folders, nil
+}
+
+// Passes the folders in r to fn one at a time, in the format decodeFolders picks for path
+func streamFoldersFrom(path string, r io.Reader, fn func(Folder) error) error {
+	if !isCSVPath(path) {
+		return StreamFolders(r, fn)
+	}
+	cr, err := NewCSVReader(r)
+	if err != nil {
+		return err
+	}
+	for {
+		f, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+}
+
+// Encodes folders in the format picked by the extension of path,
+// CSV for .csv, JSON Lines for .jsonl and a JSON array otherwise
+func encodeFolders(path string, folders []Folder) ([]byte, error) {
+	var buf bytes.Buffer
+	switch {
+	case isCSVPath(path):
+		if err := WriteFoldersCSV(&buf, folders); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case strings.EqualFold(filepath.Ext(path), ".jsonl"):
+		enc := json.NewEncoder(&buf)
+		for _, f := range folders {
+			if err := enc.Encode(f); err != nil {
+				return nil, err
+			}
+		}
+		return buf.Bytes(), nil
+	}
+	return json.MarshalIndent(folders, "", "\t")
+}
+
+func isCSVPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".csv")
+}
+
+// Writes b to a temp file next to path and renames it over path,
+// readers either see the old file or the new one, never a partial write.
+// An existing file keeps its permissions, a new file is created 0644.
+func writeFileAtomic(path string, b []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	// no-op once the rename has succeeded
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// keep the mode of the file being replaced, a 0600 file must not become world readable
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Returned by a StoredDriver mutation when the change was applied in memory but
+// writing it to the store failed. The result of the mutation is still returned
+// and the change stays pending until a later Flush succeeds.
+var ErrNotFlushed = errors.New("change applied but not flushed")
+
+// IDriver backed by a FileStore so the folder hierarchy survives restarts.
+// With autoFlush every successful mutation is written straight away, and a failed
+// write is reported as ErrNotFlushed. Otherwise changes are kept in memory until
+// Flush is called.
+type StoredDriver struct {
+	IDriver
+	driver    *driver
+	store     *FileStore
+	autoFlush bool
+
+	// serialises writes to the store
+	mu    sync.Mutex
+	dirty bool
+}
+
+// Loads the folders in store into a new driver
+func OpenStoredDriver(store *FileStore, autoFlush bool, opts ...Option) (*StoredDriver, error) {
+	d, err := store.LoadDriver(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("openStoredDriver: %w", err)
+	}
+	return &StoredDriver{
+		IDriver:   d,
+		driver:    d.(*driver),
+		store:     store,
+		autoFlush: autoFlush,
+	}, nil
+}
+
+// Writes the current folders to the store if anything changed since the last write
+func (s *StoredDriver) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.dirty {
+		return nil
+	}
+	if err := s.store.Save(s.driver.snapshot()); err != nil {
+		return err
+	}
+	s.driver.logger.Debug("StoredDriver: flushed folders", "path", s.store.Path())
+	s.dirty = false
+	return nil
+}
+
+// Records a successful mutation and writes it out when autoFlush is set
+func (s *StoredDriver) changed() error {
+	s.mu.Lock()
+	s.dirty = true
+	s.mu.Unlock()
+
+	if !s.autoFlush {
+		return nil
+	}
+	if err := s.Flush(); err != nil {
+		return fmt.Errorf("storedDriver: %w: %w", ErrNotFlushed, err)
+	}
+	return nil
+}
+
+func (s *StoredDriver) MoveFolder(name string, dst string) ([]Folder, error) {
+	res, err := s.IDriver.MoveFolder(name, dst)
+	if err != nil {
+		return nil, err
+	}
+	return res, s.changed()
+}
+
+func (s *StoredDriver) MoveFolderInOrg(orgID uuid.UUID, name string, dst string) ([]Folder, error) {
+	res, err := s.IDriver.MoveFolderInOrg(orgID, name, dst)
+	if err != nil {
+		return nil, err
+	}
+	return res, s.changed()
+}
+
+func (s *StoredDriver) CreateFolder(orgID uuid.UUID, parentName string, name string) (Folder, error) {
+	res, err := s.IDriver.CreateFolder(orgID, parentName, name)
+	if err != nil {
+		return Folder{}, err
+	}
+	return res, s.changed()
+}
+
+func (s *StoredDriver) DeleteFolder(orgID uuid.UUID, name string, opts DeleteOptions) ([]Folder, error) {
+	res, err := s.IDriver.DeleteFolder(orgID, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	return res, s.changed()
+}
+
+func (s *StoredDriver) RenameFolder(orgID uuid.UUID, oldName string, newName string) ([]Folder, error) {
+	res, err := s.IDriver.RenameFolder(orgID, oldName, newName)
+	if err != nil {
+		return nil, err
+	}
+	return res, s.changed()
+}
+
+func (s *StoredDriver) CopyFolder(orgID uuid.UUID, src string, dst string, namingFn NamingFunc) ([]Folder, error) {
+	res, err := s.IDriver.CopyFolder(orgID, src, dst, namingFn)
+	if err != nil {
+		return nil, err
+	}
+	return res, s.changed()
+}
+
+func (s *StoredDriver) MoveFolderAcrossOrgs(srcOrg uuid.UUID, name string, dstOrg uuid.UUID, dst string, policy ConflictPolicy) ([]Folder, error) {
+	res, err := s.IDriver.MoveFolderAcrossOrgs(srcOrg, name, dstOrg, dst, policy)
+	if err != nil {
+		return nil, err
+	}
+	return res, s.changed()
+}
+
+func (s *StoredDriver) CopyFolderAcrossOrgs(srcOrg uuid.UUID, name string, dstOrg uuid.UUID, dst string, policy ConflictPolicy) ([]Folder, error) {
+	res, err := s.IDriver.CopyFolderAcrossOrgs(srcOrg, name, dstOrg, dst, policy)
+	if err != nil {
+		return nil, err
+	}
+	return res, s.changed()
+}
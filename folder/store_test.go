@@ -0,0 +1,265 @@
+package folder_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_FileStore(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	folders := []folder.Folder{
+		{Name: "alpha", Paths: "alpha", OrgId: orgId},
+		{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId},
+	}
+
+	t.Run("save and load round trip", func(t *testing.T) {
+		dir := t.TempDir()
+		store := folder.NewFileStore(filepath.Join(dir, "folders.json"))
+
+		if err := store.Save(folders); err != nil {
+			t.Fatalf("Save() received unexpected error: %v", err)
+		}
+		got, err := store.Load()
+		if err != nil {
+			t.Fatalf("Load() received unexpected error: %v", err)
+		}
+		if !compareFolders(got, folders) {
+			t.Errorf("Load() = %v, want %v", got, folders)
+		}
+
+		// only the target file remains, temp files are renamed or cleaned up
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("ReadDir() received unexpected error: %v", err)
+		}
+		if len(entries) != 1 {
+			t.Errorf("directory holds %d entries after Save(), want 1", len(entries))
+		}
+		info, err := os.Stat(store.Path())
+		if err != nil {
+			t.Fatalf("Stat() received unexpected error: %v", err)
+		}
+		if info.Mode().Perm() != 0o644 {
+			t.Errorf("saved file has permissions %v, want %v", info.Mode().Perm(), os.FileMode(0o644))
+		}
+	})
+
+	t.Run("csv extension saves and loads csv", func(t *testing.T) {
+		store := folder.NewFileStore(filepath.Join(t.TempDir(), "folders.csv"))
+
+		if err := store.Save(folders); err != nil {
+			t.Fatalf("Save() received unexpected error: %v", err)
+		}
+		b, err := os.ReadFile(store.Path())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(b), "name,org_id,paths\n") {
+			t.Errorf("saved file does not start with a CSV header:\n%s", b)
+		}
+		got, err := store.Load()
+		if err != nil {
+			t.Fatalf("Load() received unexpected error: %v", err)
+		}
+		if !compareFolders(got, folders) {
+			t.Errorf("Load() = %v, want %v", got, folders)
+		}
+	})
+
+	t.Run("jsonl extension saves and loads JSON lines", func(t *testing.T) {
+		store := folder.NewFileStore(filepath.Join(t.TempDir(), "folders.jsonl"))
+
+		if err := store.Save(folders); err != nil {
+			t.Fatalf("Save() received unexpected error: %v", err)
+		}
+		b, err := os.ReadFile(store.Path())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lines := strings.Count(string(b), "\n"); lines != len(folders) {
+			t.Errorf("saved file has %d lines, want one per folder:\n%s", lines, b)
+		}
+		got, err := store.Load()
+		if err != nil {
+			t.Fatalf("Load() received unexpected error: %v", err)
+		}
+		if !compareFolders(got, folders) {
+			t.Errorf("Load() = %v, want %v", got, folders)
+		}
+	})
+
+	t.Run("save keeps the permissions of an existing file", func(t *testing.T) {
+		store := folder.NewFileStore(filepath.Join(t.TempDir(), "folders.json"))
+		if err := os.WriteFile(store.Path(), []byte("[]"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		// WriteFile only applies the mode when creating, and is subject to the umask
+		if err := os.Chmod(store.Path(), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := store.Save(folders); err != nil {
+			t.Fatalf("Save() received unexpected error: %v", err)
+		}
+		info, err := os.Stat(store.Path())
+		if err != nil {
+			t.Fatalf("Stat() received unexpected error: %v", err)
+		}
+		if info.Mode().Perm() != 0o600 {
+			t.Errorf("saved file has permissions %v, want %v", info.Mode().Perm(), os.FileMode(0o600))
+		}
+	})
+
+	t.Run("load driver streams every format into a driver", func(t *testing.T) {
+		for _, name := range []string{"folders.json", "folders.jsonl", "folders.csv"} {
+			store := folder.NewFileStore(filepath.Join(t.TempDir(), name))
+			if err := store.Save(folders); err != nil {
+				t.Fatalf("Save() received unexpected error: %v", err)
+			}
+			driver, err := store.LoadDriver()
+			if err != nil {
+				t.Fatalf("LoadDriver() on %s received unexpected error: %v", name, err)
+			}
+			if got := driver.GetFoldersByOrgID(orgId); !compareFolders(got, folders) {
+				t.Errorf("LoadDriver() on %s holds %v, want %v", name, got, folders)
+			}
+		}
+	})
+
+	t.Run("load driver rejects a duplicate name", func(t *testing.T) {
+		store := folder.NewFileStore(filepath.Join(t.TempDir(), "folders.jsonl"))
+		if err := store.Save(append(folders, folders[0])); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.LoadDriver(); !errors.Is(err, folder.ErrDuplicateName) {
+			t.Errorf("LoadDriver() error = %v, want %v", err, folder.ErrDuplicateName)
+		}
+	})
+
+	t.Run("missing file loads no folders", func(t *testing.T) {
+		store := folder.NewFileStore(filepath.Join(t.TempDir(), "missing.json"))
+		got, err := store.Load()
+		if err != nil {
+			t.Fatalf("Load() received unexpected error: %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("Load() = %v, want no folders", got)
+		}
+		driver, err := store.LoadDriver()
+		if err != nil {
+			t.Fatalf("LoadDriver() received unexpected error: %v", err)
+		}
+		if got := driver.GetFoldersByOrgID(orgId); len(got) != 0 {
+			t.Errorf("LoadDriver() holds %v, want no folders", got)
+		}
+	})
+
+	t.Run("malformed file returns an error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "folders.json")
+		if err := os.WriteFile(path, []byte("[{"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := folder.NewFileStore(path).Load(); err == nil {
+			t.Errorf("Load() expected an error but got none")
+		}
+	})
+}
+
+func Test_folder_StoredDriver(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name          string
+		autoFlush     bool
+		flush         bool
+		wantPersisted bool
+	}{
+		{name: "auto flush persists every mutation", autoFlush: true, wantPersisted: true},
+		{name: "changes are kept in memory until flush", autoFlush: false, wantPersisted: false},
+		{name: "flush persists pending changes", autoFlush: false, flush: true, wantPersisted: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			store := folder.NewFileStore(filepath.Join(t.TempDir(), "folders.json"))
+			if err := store.Save([]folder.Folder{{Name: "alpha", Paths: "alpha", OrgId: orgId}}); err != nil {
+				t.Fatal(err)
+			}
+
+			driver, err := folder.OpenStoredDriver(store, tt.autoFlush)
+			if err != nil {
+				t.Fatalf("OpenStoredDriver() received unexpected error: %v", err)
+			}
+			if _, err := driver.CreateFolder(orgId, "alpha", "bravo"); err != nil {
+				t.Fatalf("CreateFolder() received unexpected error: %v", err)
+			}
+			if _, err := driver.MoveFolderInOrg(orgId, "bravo", "alpha"); err != nil {
+				t.Fatalf("MoveFolderInOrg() received unexpected error: %v", err)
+			}
+			if tt.flush {
+				if err := driver.Flush(); err != nil {
+					t.Fatalf("Flush() received unexpected error: %v", err)
+				}
+			}
+
+			// reopen from disk as if the process restarted
+			reopened, err := folder.OpenStoredDriver(store, tt.autoFlush)
+			if err != nil {
+				t.Fatalf("OpenStoredDriver() received unexpected error: %v", err)
+			}
+			_, err = reopened.GetFolderByPath(orgId, "alpha.bravo")
+			if (err == nil) != tt.wantPersisted {
+				t.Errorf("GetFolderByPath() after reopen error = %v, expected folder persisted: %v", err, tt.wantPersisted)
+			}
+		})
+	}
+}
+
+func Test_folder_StoredDriver_FlushFailure(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	path := filepath.Join(t.TempDir(), "folders.json")
+
+	driver, err := folder.OpenStoredDriver(folder.NewFileStore(path), true)
+	if err != nil {
+		t.Fatalf("OpenStoredDriver() received unexpected error: %v", err)
+	}
+
+	// a non-empty directory in place of the data file makes every save fail
+	if err := os.MkdirAll(filepath.Join(path, "blocker"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	res, err := driver.CreateFolder(orgId, "", "alpha")
+	if !errors.Is(err, folder.ErrNotFlushed) {
+		t.Fatalf("CreateFolder() with failing save error = %v, want %v", err, folder.ErrNotFlushed)
+	}
+	// the change is applied and its result returned even though it was not saved
+	if res.Name != "alpha" {
+		t.Errorf("CreateFolder() = %v, want the created folder", res)
+	}
+	if _, err := driver.GetFolderByPath(orgId, "alpha"); err != nil {
+		t.Errorf("GetFolderByPath() after failed save received unexpected error: %v", err)
+	}
+
+	// the change stays pending and is written by the next successful flush
+	if err := os.RemoveAll(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := driver.Flush(); err != nil {
+		t.Fatalf("Flush() received unexpected error: %v", err)
+	}
+	saved, err := folder.NewFileStore(path).Load()
+	if err != nil {
+		t.Fatalf("Load() received unexpected error: %v", err)
+	}
+	if len(saved) != 1 || saved[0].Name != "alpha" {
+		t.Errorf("Load() = %v, want the folder created before the failed save", saved)
+	}
+}
@@ -0,0 +1,274 @@
+package folder
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+)
+
+// Decides what happens when a folder moved or copied into another org
+// has the same name as a folder that already exists in that org
+type ConflictPolicy int
+
+const (
+	// ConflictFail aborts the whole operation on the first name collision
+	ConflictFail ConflictPolicy = iota
+	// ConflictRename gives colliding folders a new, unused name
+	ConflictRename
+	// ConflictMerge merges a colliding folder into the existing folder when both
+	// sit at the same position in the target tree, any other collision fails
+	ConflictMerge
+)
+
+func (p ConflictPolicy) String() string {
+	switch p {
+	case ConflictFail:
+		return "fail"
+	case ConflictRename:
+		return "rename"
+	case ConflictMerge:
+		return "merge"
+	}
+	return fmt.Sprintf("ConflictPolicy(%d)", int(p))
+}
+
+// Parses the String form of a ConflictPolicy
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	for _, p := range []ConflictPolicy{ConflictFail, ConflictRename, ConflictMerge} {
+		if p.String() == s {
+			return p, nil
+		}
+	}
+	return ConflictFail, fmt.Errorf("unknown conflict policy '%s', expected fail, rename or merge", s)
+}
+
+// Planned outcome for a single folder of the transferred subtree
+type transferStep struct {
+	// name the folder takes in the target org
+	name string
+	// existing folder in the target org that this folder is merged into
+	mergeInto *Folder
+}
+
+// Moves the subtree rooted at name in srcOrg under dst in dstOrg, an empty dst moves it to the root.
+// OrgId is reassigned on every moved folder and name collisions are resolved through policy.
+// Returns the new folder structure once the move has occurred.
+func (d *driver) MoveFolderAcrossOrgs(srcOrg uuid.UUID, name string, dstOrg uuid.UUID, dst string, policy ConflictPolicy) ([]Folder, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	srcFolder, dstFolder, plan, err := d.planTransfer(srcOrg, name, dstOrg, dst, policy, "_moved")
+	if err != nil {
+		return nil, fmt.Errorf("moveFolderAcrossOrgs: %w", err)
+	}
+
+	// remove srcFolder from its current parent
+	if srcFolder.Parent != nil {
+		if err := d.removeChild(srcFolder.Parent, srcFolder); err != nil {
+			return nil, fmt.Errorf("moveFolderAcrossOrgs: error removing source folder from its current parent: %w", err)
+		}
+	}
+
+	// folders leaving srcOrg, and merged folders that leave the driver entirely
+	leftSrcOrg := make(map[*Folder]bool)
+	dropped := make(map[*Folder]bool)
+
+	var apply func(currFolder *Folder, newParent *Folder)
+	apply = func(currFolder *Folder, newParent *Folder) {
+		step := plan[currFolder]
+		children := currFolder.Children
+		currFolder.Children = nil
+
+		delete(d.pathIndex, pathKey{srcOrg, currFolder.Paths})
+		leftSrcOrg[currFolder] = true
+
+		if step.mergeInto != nil {
+			dropped[currFolder] = true
+			currFolder.Parent = nil
+			d.nameIndex[currFolder.Name] = removeFolder(d.nameIndex[currFolder.Name], currFolder)
+			for _, child := range children {
+				apply(child, step.mergeInto)
+			}
+			return
+		}
+
+		if step.name != currFolder.Name {
+			d.nameIndex[currFolder.Name] = removeFolder(d.nameIndex[currFolder.Name], currFolder)
+			if len(d.nameIndex[currFolder.Name]) == 0 {
+				delete(d.nameIndex, currFolder.Name)
+			}
+			d.nameIndex[step.name] = append(d.nameIndex[step.name], currFolder)
+			currFolder.Name = step.name
+		}
+		d.attachTransferred(currFolder, dstOrg, newParent)
+
+		for _, child := range children {
+			apply(child, currFolder)
+		}
+	}
+	apply(srcFolder, dstFolder)
+
+	if len(d.nameIndex[name]) == 0 {
+		delete(d.nameIndex, name)
+	}
+	d.orgIdIndex[srcOrg] = filterFolders(d.orgIdIndex[srcOrg], leftSrcOrg)
+	if len(d.orgIdIndex[srcOrg]) == 0 {
+		delete(d.orgIdIndex, srcOrg)
+	}
+	if len(dropped) > 0 {
+		d.folders = filterFolders(d.folders, dropped)
+	}
+
+	return d.getAllFolders(), nil
+}
+
+// Copies the subtree rooted at name in srcOrg under dst in dstOrg, an empty dst copies it to the root.
+// The source subtree is left untouched and name collisions are resolved through policy.
+// Returns the newly created folders, parents before children.
+func (d *driver) CopyFolderAcrossOrgs(srcOrg uuid.UUID, name string, dstOrg uuid.UUID, dst string, policy ConflictPolicy) ([]Folder, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	srcFolder, dstFolder, plan, err := d.planTransfer(srcOrg, name, dstOrg, dst, policy, "_copy")
+	if err != nil {
+		return nil, fmt.Errorf("copyFolderAcrossOrgs: %w", err)
+	}
+
+	created := []Folder{}
+	var apply func(original *Folder, newParent *Folder)
+	apply = func(original *Folder, newParent *Folder) {
+		step := plan[original]
+		if step.mergeInto != nil {
+			for _, child := range original.Children {
+				apply(child, step.mergeInto)
+			}
+			return
+		}
+
+		newFolder := &Folder{Name: step.name}
+		d.folders = append(d.folders, newFolder)
+		d.nameIndex[step.name] = append(d.nameIndex[step.name], newFolder)
+		d.attachTransferred(newFolder, dstOrg, newParent)
+		created = append(created, *newFolder)
+
+		for _, child := range original.Children {
+			apply(child, newFolder)
+		}
+	}
+	apply(srcFolder, dstFolder)
+
+	return created, nil
+}
+
+// Places f under newParent in dstOrg, setting its org and path and indexing it.
+// f.Name must already be final and f must already be in nameIndex and driver.folders.
+func (d *driver) attachTransferred(f *Folder, dstOrg uuid.UUID, newParent *Folder) {
+	f.OrgId = dstOrg
+	f.Parent = newParent
+	f.Paths = f.Name
+	if newParent != nil {
+		f.Paths = fmt.Sprintf("%s.%s", newParent.Paths, f.Name)
+		newParent.Children = append(newParent.Children, f)
+	}
+	d.pathIndex[pathKey{dstOrg, f.Paths}] = f
+	d.orgIdIndex[dstOrg] = append(d.orgIdIndex[dstOrg], f)
+}
+
+// Validates a cross org move or copy and decides the outcome of every folder in the subtree
+// before anything is changed, so a failing policy leaves the driver untouched
+func (d *driver) planTransfer(srcOrg uuid.UUID, name string, dstOrg uuid.UUID, dst string, policy ConflictPolicy, renameSuffix string) (*Folder, *Folder, map[*Folder]transferStep, error) {
+	if srcOrg == uuid.Nil {
+		return nil, nil, nil, &FolderError{Err: ErrInvalidOrgID, OrgID: srcOrg, msg: fmt.Sprintf("invalid source OrgID - '%s'", srcOrg)}
+	}
+	if dstOrg == uuid.Nil {
+		return nil, nil, nil, &FolderError{Err: ErrInvalidOrgID, OrgID: dstOrg, msg: fmt.Sprintf("invalid destination OrgID - '%s'", dstOrg)}
+	}
+	if srcOrg == dstOrg {
+		return nil, nil, nil, &FolderError{
+			Err:   ErrInvalidOrgID,
+			OrgID: dstOrg,
+			msg:   fmt.Sprintf("source and destination org are both '%s', use an in-org operation instead", srcOrg),
+		}
+	}
+
+	srcFolder, err := d.getFolderInOrg(srcOrg, name)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("source %w", err)
+	}
+	var dstFolder *Folder
+	if dst != "" {
+		dstFolder, err = d.getFolderInOrg(dstOrg, dst)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("destination %w", err)
+		}
+	}
+
+	// every name in the subtree is reserved so renamed folders cannot collide with later ones
+	subtreeNames := map[string]bool{srcFolder.Name: true}
+	descendents, _ := collectAllDescendents(srcFolder)
+	for _, descendent := range descendents {
+		subtreeNames[descendent.Name] = true
+	}
+	assigned := make(map[string]bool)
+	taken := func(candidate string) bool {
+		if subtreeNames[candidate] || assigned[candidate] {
+			return true
+		}
+		_, err := d.getFolderInOrg(dstOrg, candidate)
+		return err == nil
+	}
+	rename := SuffixNaming(renameSuffix)
+
+	plan := make(map[*Folder]transferStep)
+
+	// mergeParent is the existing folder in dstOrg the current level lands in,
+	// canMerge is false once the level lands in a newly placed folder
+	var planFolder func(currFolder *Folder, mergeParent *Folder, canMerge bool) error
+	planFolder = func(currFolder *Folder, mergeParent *Folder, canMerge bool) error {
+		step := transferStep{name: currFolder.Name}
+
+		if existing, err := d.getFolderInOrg(dstOrg, currFolder.Name); err == nil {
+			switch policy {
+			case ConflictFail:
+				return &FolderError{
+					Err:   ErrDuplicateName,
+					Name:  currFolder.Name,
+					OrgID: dstOrg,
+					Path:  existing.Paths,
+					msg:   fmt.Sprintf("duplicate folder name '%s' in OrgId '%s'", currFolder.Name, dstOrg),
+				}
+			case ConflictRename:
+				step.name = rename(currFolder.Name, taken)
+				assigned[step.name] = true
+			case ConflictMerge:
+				if !canMerge || existing.Parent != mergeParent {
+					return &FolderError{
+						Err:   ErrDuplicateName,
+						Name:  currFolder.Name,
+						OrgID: dstOrg,
+						Path:  existing.Paths,
+						msg:   fmt.Sprintf("cannot merge folder '%s', it already exists at '%s' in org '%s'", currFolder.Name, existing.Paths, dstOrg),
+					}
+				}
+				step.mergeInto = existing
+			default:
+				return fmt.Errorf("unknown conflict policy %v", policy)
+			}
+		}
+		plan[currFolder] = step
+
+		// children of a merged folder land in the existing folder and may merge again
+		for _, child := range currFolder.Children {
+			if err := planFolder(child, step.mergeInto, step.mergeInto != nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := planFolder(srcFolder, dstFolder, true); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return srcFolder, dstFolder, plan, nil
+}
@@ -0,0 +1,219 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_MoveFolderAcrossOrgs(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name         string
+		source       string
+		destination  string
+		policy       folder.ConflictPolicy
+		extraFolders []folder.Folder
+		wantOrg1     []folder.Folder
+		wantOrg2     []folder.Folder
+		wantRunError bool
+	}{
+		// Functionalities
+		{
+			name:        "move subtree into a folder of another org",
+			source:      "bravo",
+			destination: "foxtrot",
+			policy:      folder.ConflictFail,
+			wantOrg1: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+			},
+			wantOrg2: []folder.Folder{
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+				{Name: "bravo", Paths: "foxtrot.bravo", OrgId: orgId2},
+				{Name: "charlie", Paths: "foxtrot.bravo.charlie", OrgId: orgId2},
+			},
+		},
+		{
+			name:        "move subtree to the root of another org",
+			source:      "bravo",
+			destination: "",
+			policy:      folder.ConflictFail,
+			wantOrg1: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+			},
+			wantOrg2: []folder.Folder{
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+				{Name: "bravo", Paths: "bravo", OrgId: orgId2},
+				{Name: "charlie", Paths: "bravo.charlie", OrgId: orgId2},
+			},
+		},
+		{
+			name:        "rename policy renames colliding folders",
+			source:      "bravo",
+			destination: "foxtrot",
+			policy:      folder.ConflictRename,
+			extraFolders: []folder.Folder{
+				{Name: "charlie", Paths: "foxtrot.charlie", OrgId: orgId2},
+			},
+			wantOrg1: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+			},
+			wantOrg2: []folder.Folder{
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+				{Name: "charlie", Paths: "foxtrot.charlie", OrgId: orgId2},
+				{Name: "bravo", Paths: "foxtrot.bravo", OrgId: orgId2},
+				{Name: "charlie_moved", Paths: "foxtrot.bravo.charlie_moved", OrgId: orgId2},
+			},
+		},
+		{
+			name:        "merge policy merges folders at the same position",
+			source:      "bravo",
+			destination: "foxtrot",
+			policy:      folder.ConflictMerge,
+			extraFolders: []folder.Folder{
+				{Name: "bravo", Paths: "foxtrot.bravo", OrgId: orgId2},
+				{Name: "golf", Paths: "foxtrot.bravo.golf", OrgId: orgId2},
+			},
+			wantOrg1: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+			},
+			wantOrg2: []folder.Folder{
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+				{Name: "bravo", Paths: "foxtrot.bravo", OrgId: orgId2},
+				{Name: "golf", Paths: "foxtrot.bravo.golf", OrgId: orgId2},
+				{Name: "charlie", Paths: "foxtrot.bravo.charlie", OrgId: orgId2},
+			},
+		},
+
+		// edge cases and error checking
+		{
+			name:        "error on collision with fail policy",
+			source:      "bravo",
+			destination: "foxtrot",
+			policy:      folder.ConflictFail,
+			extraFolders: []folder.Folder{
+				{Name: "charlie", Paths: "foxtrot.charlie", OrgId: orgId2},
+			},
+			wantRunError: true,
+		},
+		{
+			name:        "error merging a folder that exists at a different position",
+			source:      "bravo",
+			destination: "foxtrot",
+			policy:      folder.ConflictMerge,
+			extraFolders: []folder.Folder{
+				{Name: "charlie", Paths: "foxtrot.charlie", OrgId: orgId2},
+			},
+			wantRunError: true,
+		},
+		{
+			name:         "error moving to a destination that does not exist in the target org",
+			source:       "bravo",
+			destination:  "delta",
+			policy:       folder.ConflictFail,
+			wantRunError: true,
+		},
+		{
+			name:         "error moving a source that does not exist in the source org",
+			source:       "foxtrot",
+			destination:  "",
+			policy:       folder.ConflictFail,
+			wantRunError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := folder.NewDriver(append([]folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+			}, tt.extraFolders...))
+			if err != nil {
+				t.Fatalf("driver failed to initialise - %v", err)
+			}
+			before := len(driver.GetFoldersByOrgID(orgId1)) + len(driver.GetFoldersByOrgID(orgId2))
+
+			_, err = driver.MoveFolderAcrossOrgs(orgId1, tt.source, orgId2, tt.destination, tt.policy)
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("MoveFolderAcrossOrgs() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if tt.wantRunError {
+				// a failed move must leave the driver unchanged
+				after := len(driver.GetFoldersByOrgID(orgId1)) + len(driver.GetFoldersByOrgID(orgId2))
+				if after != before {
+					t.Errorf("driver holds %d folders after failed move, want %d", after, before)
+				}
+				return
+			}
+
+			if got := driver.GetFoldersByOrgID(orgId1); !compareFolders(got, tt.wantOrg1) {
+				t.Errorf("GetFoldersByOrgID(srcOrg) = %v, want %v", got, tt.wantOrg1)
+			}
+			if got := driver.GetFoldersByOrgID(orgId2); !compareFolders(got, tt.wantOrg2) {
+				t.Errorf("GetFoldersByOrgID(dstOrg) = %v, want %v", got, tt.wantOrg2)
+			}
+
+			// moved folders are indexed under their new org and path
+			for _, f := range tt.wantOrg2 {
+				if _, err := driver.GetFolderByPath(orgId2, f.Paths); err != nil {
+					t.Errorf("GetFolderByPath() received unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func Test_folder_CopyFolderAcrossOrgs(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	driver, err := folder.NewDriver([]folder.Folder{
+		{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+		{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+		{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+		{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+		{Name: "charlie", Paths: "foxtrot.charlie", OrgId: orgId2},
+	})
+	if err != nil {
+		t.Fatalf("driver failed to initialise - %v", err)
+	}
+
+	if _, err := driver.CopyFolderAcrossOrgs(orgId1, "bravo", orgId2, "foxtrot", folder.ConflictFail); err == nil {
+		t.Errorf("CopyFolderAcrossOrgs() with fail policy expected an error but got none")
+	}
+
+	got, err := driver.CopyFolderAcrossOrgs(orgId1, "bravo", orgId2, "foxtrot", folder.ConflictRename)
+	if err != nil {
+		t.Fatalf("CopyFolderAcrossOrgs() received unexpected error: %v", err)
+	}
+	want := []folder.Folder{
+		{Name: "bravo", Paths: "foxtrot.bravo", OrgId: orgId2},
+		{Name: "charlie_copy", Paths: "foxtrot.bravo.charlie_copy", OrgId: orgId2},
+	}
+	if !compareFolders(got, want) {
+		t.Errorf("CopyFolderAcrossOrgs() = %v, want %v", got, want)
+	}
+
+	// the source subtree is untouched
+	children, err := driver.GetAllChildFolders(orgId1, "bravo")
+	if err != nil {
+		t.Fatalf("GetAllChildFolders() received unexpected error: %v", err)
+	}
+	wantChildren := []folder.Folder{{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1}}
+	if !compareFolders(children, wantChildren) {
+		t.Errorf("GetAllChildFolders() on source = %v, want %v", children, wantChildren)
+	}
+}
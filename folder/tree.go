@@ -0,0 +1,137 @@
+package folder
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gofrs/uuid"
+)
+
+// Nested view of a folder and its subtree, Folder itself only marshals as a flat list
+type TreeNode struct {
+	Name     string     `json:"name"`
+	Path     string     `json:"path"`
+	OrgId    uuid.UUID  `json:"org_id"`
+	Children []TreeNode `json:"children"`
+}
+
+// Returns every root folder of orgID as a nested tree, roots and children are ordered by name
+func (d *driver) BuildTree(orgID uuid.UUID) ([]TreeNode, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if orgID == uuid.Nil {
+		return nil, invalidOrgIDError("buildTree", orgID)
+	}
+
+	roots := []*Folder{}
+	for _, folder := range d.orgIdIndex[orgID] {
+		if folder.Parent == nil {
+			roots = append(roots, folder)
+		}
+	}
+	sortFoldersByName(roots)
+
+	nodes := make([]TreeNode, 0, len(roots))
+	for _, root := range roots {
+		nodes = append(nodes, newTreeNode(root))
+	}
+	return nodes, nil
+}
+
+// Returns name and its descendents as a nested tree
+func (d *driver) SubTree(orgID uuid.UUID, name string) (TreeNode, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if orgID == uuid.Nil {
+		return TreeNode{}, invalidOrgIDError("subTree", orgID)
+	}
+
+	folder, err := d.getFolderInOrg(orgID, name)
+	if err != nil {
+		return TreeNode{}, fmt.Errorf("subTree: %w", err)
+	}
+	return newTreeNode(folder), nil
+}
+
+func newTreeNode(folder *Folder) TreeNode {
+	node := TreeNode{
+		Name:     folder.Name,
+		Path:     folder.Paths,
+		OrgId:    folder.OrgId,
+		Children: make([]TreeNode, 0, len(folder.Children)),
+	}
+	for _, child := range sortedChildren(folder) {
+		node.Children = append(node.Children, newTreeNode(child))
+	}
+	return node
+}
+
+// Reads nested tree JSON, either a single node or an array of nodes, into the flat form NewDriver accepts
+func DecodeTree(r io.Reader) ([]Folder, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decodeTree: %w", err)
+	}
+
+	var nodes []TreeNode
+	var err error
+	if raw[0] == '[' {
+		err = json.Unmarshal(raw, &nodes)
+	} else {
+		var node TreeNode
+		err = json.Unmarshal(raw, &node)
+		nodes = []TreeNode{node}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decodeTree: %w", err)
+	}
+	return FlattenTree(nodes...)
+}
+
+// Converts nested nodes back into a flat list of folders in pre-order.
+// Paths are rebuilt from the node names, a node's path is checked if set and
+// a node without an org_id inherits the org of its parent.
+func FlattenTree(nodes ...TreeNode) ([]Folder, error) {
+	res := []Folder{}
+	var flatten func(node TreeNode, parent *Folder) error
+	flatten = func(node TreeNode, parent *Folder) error {
+		if err := validateName(node.Name); err != nil {
+			return fmt.Errorf("flattenTree: %w", err)
+		}
+
+		f := Folder{Name: node.Name, OrgId: node.OrgId, Paths: node.Name}
+		if parent != nil {
+			f.Paths = parent.Paths + "." + node.Name
+			if f.OrgId == uuid.Nil {
+				f.OrgId = parent.OrgId
+			}
+			if f.OrgId != parent.OrgId {
+				return fmt.Errorf("flattenTree: folder '%s' is in org '%s' but its parent '%s' is in org '%s'", f.Name, f.OrgId, parent.Name, parent.OrgId)
+			}
+		}
+		if f.OrgId == uuid.Nil {
+			return invalidOrgIDError("flattenTree", f.OrgId)
+		}
+		if node.Path != "" && node.Path != f.Paths {
+			return fmt.Errorf("flattenTree: folder '%s' has path '%s' but its position in the tree gives '%s'", f.Name, node.Path, f.Paths)
+		}
+
+		res = append(res, f)
+		for _, child := range node.Children {
+			if err := flatten(child, &f); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, node := range nodes {
+		if err := flatten(node, nil); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
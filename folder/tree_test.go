@@ -0,0 +1,241 @@
+package folder_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func newTreeTestDriver(t *testing.T, orgId1, orgId2 uuid.UUID) (folder.IDriver, []folder.Folder) {
+	t.Helper()
+	folders := []folder.Folder{
+		{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+		{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+		{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+		{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+		{Name: "echo", Paths: "echo", OrgId: orgId1},
+		{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+	}
+	driver, err := folder.NewDriver(folders)
+	if err != nil {
+		t.Fatalf("driver failed to initialise - %v", err)
+	}
+	return driver, folders
+}
+
+func Test_folder_BuildTree(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+	driver, _ := newTreeTestDriver(t, orgId1, orgId2)
+
+	nodes, err := driver.BuildTree(orgId1)
+	if err != nil {
+		t.Fatalf("BuildTree() received unexpected error: %v", err)
+	}
+	got, err := json.Marshal(nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	org := orgId1.String()
+	want := `[` +
+		`{"name":"alpha","path":"alpha","org_id":"` + org + `","children":[` +
+		`{"name":"bravo","path":"alpha.bravo","org_id":"` + org + `","children":[` +
+		`{"name":"charlie","path":"alpha.bravo.charlie","org_id":"` + org + `","children":[]}]},` +
+		`{"name":"delta","path":"alpha.delta","org_id":"` + org + `","children":[]}]},` +
+		`{"name":"echo","path":"echo","org_id":"` + org + `","children":[]}]`
+	if string(got) != want {
+		t.Errorf("BuildTree() JSON =\n%s\nwant\n%s", got, want)
+	}
+
+	if nodes, err := driver.BuildTree(uuid.Must(uuid.NewV4())); err != nil || len(nodes) != 0 {
+		t.Errorf("BuildTree() of an empty org = %v, %v, want no nodes", nodes, err)
+	}
+	if _, err := driver.BuildTree(uuid.Nil); err == nil {
+		t.Errorf("BuildTree() with a nil orgID expected an error")
+	}
+}
+
+func Test_folder_SubTree(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name         string
+		orgID        uuid.UUID
+		folderName   string
+		want         folder.TreeNode
+		wantRunError bool
+	}{
+		{
+			name:       "folder with descendents",
+			orgID:      orgId1,
+			folderName: "bravo",
+			want: folder.TreeNode{Name: "bravo", Path: "alpha.bravo", OrgId: orgId1, Children: []folder.TreeNode{
+				{Name: "charlie", Path: "alpha.bravo.charlie", OrgId: orgId1, Children: []folder.TreeNode{}},
+			}},
+		},
+		{
+			name:       "leaf folder",
+			orgID:      orgId1,
+			folderName: "delta",
+			want:       folder.TreeNode{Name: "delta", Path: "alpha.delta", OrgId: orgId1, Children: []folder.TreeNode{}},
+		},
+		{
+			name:         "folder in another org",
+			orgID:        orgId1,
+			folderName:   "foxtrot",
+			wantRunError: true,
+		},
+		{
+			name:         "invalid orgID",
+			orgID:        uuid.Nil,
+			folderName:   "alpha",
+			wantRunError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver, _ := newTreeTestDriver(t, orgId1, orgId2)
+
+			got, err := driver.SubTree(tt.orgID, tt.folderName)
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("SubTree() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if tt.wantRunError {
+				return
+			}
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(tt.want)
+			if !bytes.Equal(gotJSON, wantJSON) {
+				t.Errorf("SubTree() = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func Test_folder_DecodeTree(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+	org := orgId1.String()
+
+	tests := []struct {
+		name         string
+		input        string
+		want         []folder.Folder
+		wantRunError bool
+	}{
+		// Functionalities
+		{
+			name:  "single node inherits its org",
+			input: `{"name": "alpha", "org_id": "` + org + `", "children": [{"name": "bravo", "children": [{"name": "charlie"}]}]}`,
+			want: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+			},
+		},
+		{
+			name: "array of nodes with paths",
+			input: `[
+				{"name": "alpha", "path": "alpha", "org_id": "` + org + `", "children": [{"name": "bravo", "path": "alpha.bravo"}]},
+				{"name": "echo", "path": "echo", "org_id": "` + org + `"}
+			]`,
+			want: []folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "echo", Paths: "echo", OrgId: orgId1},
+			},
+		},
+		{
+			name:  "empty array",
+			input: `[]`,
+			want:  []folder.Folder{},
+		},
+
+		// error checking
+		{
+			name:         "path disagrees with position",
+			input:        `{"name": "alpha", "org_id": "` + org + `", "children": [{"name": "bravo", "path": "bravo"}]}`,
+			wantRunError: true,
+		},
+		{
+			name:         "child in a different org",
+			input:        `{"name": "alpha", "org_id": "` + org + `", "children": [{"name": "bravo", "org_id": "` + orgId2.String() + `"}]}`,
+			wantRunError: true,
+		},
+		{
+			name:         "root without an org",
+			input:        `{"name": "alpha"}`,
+			wantRunError: true,
+		},
+		{
+			name:         "name containing a dot",
+			input:        `{"name": "alpha.bravo", "org_id": "` + org + `"}`,
+			wantRunError: true,
+		},
+		{
+			name:         "malformed JSON",
+			input:        `{"name": "alpha"`,
+			wantRunError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := folder.DecodeTree(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantRunError {
+				t.Errorf("DecodeTree() error = %v, expected error presence: %v", err, tt.wantRunError)
+				return
+			}
+			if tt.wantRunError {
+				return
+			}
+			if !compareFolders(got, tt.want) {
+				t.Errorf("DecodeTree() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_folder_DecodeTree_RoundTrip(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+	driver, folders := newTreeTestDriver(t, orgId1, orgId2)
+
+	nodes, err := driver.BuildTree(orgId1)
+	if err != nil {
+		t.Fatalf("BuildTree() received unexpected error: %v", err)
+	}
+	data, err := json.Marshal(nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := folder.DecodeTree(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("DecodeTree() received unexpected error: %v", err)
+	}
+	want := []folder.Folder{}
+	for _, f := range folders {
+		if f.OrgId == orgId1 {
+			want = append(want, f)
+		}
+	}
+	if !compareFolders(got, want) {
+		t.Errorf("DecodeTree(BuildTree()) = %v, want %v", got, want)
+	}
+	if _, err := folder.NewDriver(got); err != nil {
+		t.Errorf("NewDriver() rejected decoded folders: %v", err)
+	}
+}
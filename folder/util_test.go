@@ -38,3 +38,13 @@ func lessThanComparator(f1, f2 folder.Folder) bool {
 	}
 	return f1.OrgId.String() < f2.OrgId.String()
 }
+
+// checks whether folders contains a folder matching want on name, path and orgId
+func containsFolder(folders []folder.Folder, want folder.Folder) bool {
+	for _, f := range folders {
+		if f.Name == want.Name && f.Paths == want.Paths && f.OrgId == want.OrgId {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,533 @@
+package folder
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+// Operations recorded in the write-ahead log
+const (
+	opSnapshot       = "snapshot"
+	opCreate         = "create"
+	opMove           = "move"
+	opRename         = "rename"
+	opDelete         = "delete"
+	opCopy           = "copy"
+	opMoveAcrossOrgs = "move_across_orgs"
+	opCopyAcrossOrgs = "copy_across_orgs"
+)
+
+const (
+	// appended to the snapshot path to name its log
+	walSuffix = ".wal"
+	// each record starts with its crc32 as fixed width hex
+	walChecksumHexWidth = 8
+)
+
+// A single line of the write-ahead log, stored as "<crc32 of json> <json>\n".
+// The first line of every log is an opSnapshot header holding the checksum of
+// the snapshot the log applies on top of.
+type logRecord struct {
+	Seq       uint64         `json:"seq"`
+	Op        string         `json:"op"`
+	OrgID     uuid.UUID      `json:"org_id"`
+	Name      string         `json:"name,omitempty"`
+	Dst       string         `json:"dst,omitempty"`
+	DstOrgID  uuid.UUID      `json:"dst_org_id"`
+	NewName   string         `json:"new_name,omitempty"`
+	Recursive bool           `json:"recursive,omitempty"`
+	Policy    ConflictPolicy `json:"policy,omitempty"`
+	// names handed out by the naming function of a copy, in pre-order
+	Names []string `json:"names,omitempty"`
+	// checksum of the snapshot file, only set on the opSnapshot header
+	SnapshotChecksum uint32 `json:"snapshot_checksum,omitempty"`
+}
+
+// IDriver that appends every mutation to a write-ahead log before applying it.
+// On open the snapshot is loaded and the log replayed on top of it, and the log is
+// compacted into a new snapshot (in the sample.json format) every compactEvery records.
+type LoggedDriver struct {
+	IDriver
+	driver       *driver
+	snapshotPath string
+	logPath      string
+	compactEvery int
+
+	// serialises mutations so the log order matches the order they are applied in
+	mu      sync.Mutex
+	log     *os.File
+	seq     uint64
+	pending int
+	// set when a compaction replaced the snapshot but could not start a log for it,
+	// records appended to the old log would be discarded on the next open
+	failed error
+}
+
+// Opens the snapshot at snapshotPath and replays its log from snapshotPath + ".wal".
+// A compactEvery of 0 disables automatic compaction.
+func OpenLoggedDriver(snapshotPath string, compactEvery int, opts ...Option) (*LoggedDriver, error) {
+	d, checksum, err := loadSnapshot(snapshotPath, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("openLoggedDriver: %w", err)
+	}
+	l := &LoggedDriver{
+		IDriver:      d,
+		driver:       d.(*driver),
+		snapshotPath: snapshotPath,
+		logPath:      snapshotPath + walSuffix,
+		compactEvery: compactEvery,
+	}
+
+	if err := l.replay(checksum); err != nil {
+		return nil, fmt.Errorf("openLoggedDriver: %w", err)
+	}
+
+	l.log, err = os.OpenFile(l.logPath, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("openLoggedDriver: %w", err)
+	}
+	return l, nil
+}
+
+// Streams the snapshot into a new driver and returns it with the checksum of the file,
+// a missing or empty snapshot gives an empty driver
+func loadSnapshot(snapshotPath string, opts ...Option) (IDriver, uint32, error) {
+	builder := NewDriverBuilder(opts...)
+	file, err := os.Open(snapshotPath)
+	if errors.Is(err, os.ErrNotExist) {
+		d, err := builder.Build()
+		return d, crc32.ChecksumIEEE(nil), err
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	// the checksum covers every byte, including any the decoder never needed to read
+	hash := crc32.NewIEEE()
+	r := bufio.NewReader(io.TeeReader(file, hash))
+	// an empty file is an empty snapshot, even for CSV which otherwise needs a header
+	if _, err := r.Peek(1); err == nil {
+		if err := streamFoldersFrom(snapshotPath, r, builder.Add); err != nil {
+			return nil, 0, fmt.Errorf("reading snapshot '%s': %w", snapshotPath, err)
+		}
+	} else if !errors.Is(err, io.EOF) {
+		return nil, 0, err
+	}
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return nil, 0, err
+	}
+
+	d, err := builder.Build()
+	if err != nil {
+		return nil, 0, err
+	}
+	return d, hash.Sum32(), nil
+}
+
+// Replays the log on top of the loaded snapshot. A log written for an older
+// snapshot is discarded, and a torn record at the end of the log is truncated.
+func (l *LoggedDriver) replay(snapshotChecksum uint32) error {
+	records, validSize, torn, err := readLog(l.logPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return l.resetLog(snapshotChecksum)
+	}
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 || records[0].Op != opSnapshot {
+		return fmt.Errorf("replay: '%s' does not start with a snapshot header", l.logPath)
+	}
+
+	// the snapshot was compacted after this log was written, it already holds every record
+	if records[0].SnapshotChecksum != snapshotChecksum {
+		l.driver.logger.Info("replay: discarding log written for an older snapshot", "path", l.logPath)
+		l.seq = records[len(records)-1].Seq
+		return l.resetLog(snapshotChecksum)
+	}
+
+	l.seq = records[0].Seq
+	for i, rec := range records[1:] {
+		// line numbers are 1-based and the header is line 1
+		if err := applyLogRecord(l.IDriver, rec); errors.Is(err, errUnknownLogOp) {
+			return fmt.Errorf("replay: '%s' line %d: %w", l.logPath, i+2, err)
+		} else if err != nil {
+			// a rejected operation is only removed from the log after it failed, a crash
+			// in between leaves it behind and it fails again in the same way
+			l.driver.logger.Warn("replay: skipping record that failed to apply", "path", l.logPath, "line", i+2, "err", err)
+			l.seq = rec.Seq
+			continue
+		}
+		l.seq = rec.Seq
+		l.pending++
+	}
+
+	l.driver.logger.Debug("replay: applied log records", "path", l.logPath, "records", len(records)-1)
+	if torn {
+		l.driver.logger.Info("replay: truncating torn record at end of log", "path", l.logPath, "offset", validSize)
+		return os.Truncate(l.logPath, validSize)
+	}
+	return nil
+}
+
+// Reads every record in the log at path. A bad final record is a write that was
+// cut short by a crash, it is reported through torn along with the size of the
+// valid prefix. A bad record anywhere else is corruption and returns an error.
+func readLog(path string) ([]logRecord, int64, bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	defer file.Close()
+
+	records := []logRecord{}
+	reader := bufio.NewReader(file)
+	var validSize int64
+	for lineNum := 1; ; lineNum++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, 0, false, err
+		}
+		if len(line) == 0 {
+			return records, validSize, false, nil
+		}
+
+		rec, decodeErr := decodeLogRecord(line)
+		if decodeErr != nil {
+			if _, peekErr := reader.Peek(1); peekErr == io.EOF {
+				return records, validSize, true, nil
+			}
+			return nil, 0, false, fmt.Errorf("readLog: '%s' line %d: %w", path, lineNum, decodeErr)
+		}
+		records = append(records, rec)
+		validSize += int64(len(line))
+	}
+}
+
+// Replaces the log with one that only holds a header for the given snapshot
+func (l *LoggedDriver) resetLog(snapshotChecksum uint32) error {
+	header, err := encodeLogRecord(logRecord{Seq: l.seq, Op: opSnapshot, SnapshotChecksum: snapshotChecksum})
+	if err != nil {
+		return err
+	}
+	l.pending = 0
+	return writeFileAtomic(l.logPath, header)
+}
+
+// Writes the current folders as a new snapshot and starts an empty log on top of it.
+func (l *LoggedDriver) Compact() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.compact()
+}
+
+func (l *LoggedDriver) compact() error {
+	if l.failed != nil {
+		return fmt.Errorf("compact: %w", l.failed)
+	}
+	b, err := encodeFolders(l.snapshotPath, l.driver.snapshot())
+	if err != nil {
+		return fmt.Errorf("compact: %w", err)
+	}
+	// once the snapshot is renamed the old log no longer matches its checksum,
+	// so a crash before the log is reset cannot replay records twice
+	if err := writeFileAtomic(l.snapshotPath, b); err != nil {
+		return fmt.Errorf("compact: %w", err)
+	}
+
+	// from here on the snapshot holds every record, but anything appended to the old
+	// log would be lost, so a failure stops all further writes until the driver is reopened
+	fail := func(err error) error {
+		l.failed = fmt.Errorf("log not reset after writing snapshot '%s', reopen the driver: %w", l.snapshotPath, err)
+		return fmt.Errorf("compact: %w", l.failed)
+	}
+	if err := l.resetLog(crc32.ChecksumIEEE(b)); err != nil {
+		return fail(err)
+	}
+
+	// the old file handle points at the replaced log
+	if err := l.log.Close(); err != nil {
+		return fail(err)
+	}
+	l.log, err = os.OpenFile(l.logPath, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fail(err)
+	}
+	l.driver.logger.Debug("compact: wrote snapshot", "path", l.snapshotPath, "seq", l.seq)
+	return nil
+}
+
+// Closes the log file, the driver must not be mutated afterwards
+func (l *LoggedDriver) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.log.Close()
+}
+
+// Appends rec to the log, then applies it through apply.
+// A failed apply removes the record again so the log only holds operations that succeeded,
+// one left behind by a crash before the removal is skipped on replay.
+// Errors from the automatic compaction that follows are logged rather than returned,
+// unless they leave the log unusable, in which case every later write fails.
+func (l *LoggedDriver) write(rec logRecord, apply func() error) error {
+	if l.failed != nil {
+		return fmt.Errorf("writeAheadLog: %w", l.failed)
+	}
+	info, err := l.log.Stat()
+	if err != nil {
+		return fmt.Errorf("writeAheadLog: %w", err)
+	}
+
+	rec.Seq = l.seq + 1
+	line, err := encodeLogRecord(rec)
+	if err != nil {
+		return fmt.Errorf("writeAheadLog: %w", err)
+	}
+	if _, err := l.log.Write(line); err != nil {
+		return fmt.Errorf("writeAheadLog: %w", err)
+	}
+	if err := l.log.Sync(); err != nil {
+		return fmt.Errorf("writeAheadLog: %w", err)
+	}
+
+	if applyErr := apply(); applyErr != nil {
+		if err := l.log.Truncate(info.Size()); err != nil {
+			return errors.Join(applyErr, fmt.Errorf("writeAheadLog: removing failed record: %w", err))
+		}
+		return applyErr
+	}
+
+	l.seq = rec.Seq
+	l.pending++
+	if l.compactEvery > 0 && l.pending >= l.compactEvery {
+		// the record is durable in the log, so a failed compaction must not fail the
+		// mutation. pending is left as is and the next write tries again.
+		if err := l.compact(); err != nil {
+			l.driver.logger.Error("writeAheadLog: compaction failed, retrying on the next write", "path", l.snapshotPath, "err", err)
+		}
+	}
+	return nil
+}
+
+func (l *LoggedDriver) MoveFolder(name string, dst string) ([]Folder, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var res []Folder
+	err := l.write(logRecord{Op: opMove, Name: name, Dst: dst}, func() (err error) {
+		res, err = l.IDriver.MoveFolder(name, dst)
+		return err
+	})
+	return res, err
+}
+
+func (l *LoggedDriver) MoveFolderInOrg(orgID uuid.UUID, name string, dst string) ([]Folder, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var res []Folder
+	err := l.write(logRecord{Op: opMove, OrgID: orgID, Name: name, Dst: dst}, func() (err error) {
+		res, err = l.IDriver.MoveFolderInOrg(orgID, name, dst)
+		return err
+	})
+	return res, err
+}
+
+func (l *LoggedDriver) CreateFolder(orgID uuid.UUID, parentName string, name string) (Folder, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var res Folder
+	err := l.write(logRecord{Op: opCreate, OrgID: orgID, Name: name, Dst: parentName}, func() (err error) {
+		res, err = l.IDriver.CreateFolder(orgID, parentName, name)
+		return err
+	})
+	return res, err
+}
+
+func (l *LoggedDriver) DeleteFolder(orgID uuid.UUID, name string, opts DeleteOptions) ([]Folder, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var res []Folder
+	err := l.write(logRecord{Op: opDelete, OrgID: orgID, Name: name, Recursive: opts.Recursive}, func() (err error) {
+		res, err = l.IDriver.DeleteFolder(orgID, name, opts)
+		return err
+	})
+	return res, err
+}
+
+func (l *LoggedDriver) RenameFolder(orgID uuid.UUID, oldName string, newName string) ([]Folder, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var res []Folder
+	err := l.write(logRecord{Op: opRename, OrgID: orgID, Name: oldName, NewName: newName}, func() (err error) {
+		res, err = l.IDriver.RenameFolder(orgID, oldName, newName)
+		return err
+	})
+	return res, err
+}
+
+// namingFn cannot be written to the log, so the names it hands out are
+// generated up front and recorded instead
+func (l *LoggedDriver) CopyFolder(orgID uuid.UUID, src string, dst string, namingFn NamingFunc) ([]Folder, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	names := l.driver.planCopyNames(orgID, src, namingFn)
+
+	var res []Folder
+	err := l.write(logRecord{Op: opCopy, OrgID: orgID, Name: src, Dst: dst, Names: names}, func() (err error) {
+		res, err = l.IDriver.CopyFolder(orgID, src, dst, recordedNaming(names))
+		return err
+	})
+	return res, err
+}
+
+func (l *LoggedDriver) MoveFolderAcrossOrgs(srcOrg uuid.UUID, name string, dstOrg uuid.UUID, dst string, policy ConflictPolicy) ([]Folder, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var res []Folder
+	rec := logRecord{Op: opMoveAcrossOrgs, OrgID: srcOrg, Name: name, DstOrgID: dstOrg, Dst: dst, Policy: policy}
+	err := l.write(rec, func() (err error) {
+		res, err = l.IDriver.MoveFolderAcrossOrgs(srcOrg, name, dstOrg, dst, policy)
+		return err
+	})
+	return res, err
+}
+
+func (l *LoggedDriver) CopyFolderAcrossOrgs(srcOrg uuid.UUID, name string, dstOrg uuid.UUID, dst string, policy ConflictPolicy) ([]Folder, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var res []Folder
+	rec := logRecord{Op: opCopyAcrossOrgs, OrgID: srcOrg, Name: name, DstOrgID: dstOrg, Dst: dst, Policy: policy}
+	err := l.write(rec, func() (err error) {
+		res, err = l.IDriver.CopyFolderAcrossOrgs(srcOrg, name, dstOrg, dst, policy)
+		return err
+	})
+	return res, err
+}
+
+// Generates the names CopyFolder would hand out for the subtree rooted at src, in pre-order.
+// An unknown src returns no names and is left for CopyFolder to report.
+func (d *driver) planCopyNames(orgID uuid.UUID, src string, namingFn NamingFunc) []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if namingFn == nil {
+		namingFn = SuffixNaming("_copy")
+	}
+	srcFolder, err := d.getFolderInOrg(orgID, src)
+	if err != nil {
+		return nil
+	}
+
+	names := []string{}
+	assigned := make(map[string]bool)
+	taken := func(name string) bool {
+		if assigned[name] {
+			return true
+		}
+		_, err := d.getFolderInOrg(orgID, name)
+		return err == nil
+	}
+	var plan func(*Folder)
+	plan = func(currFolder *Folder) {
+		name := namingFn(currFolder.Name, taken)
+		assigned[name] = true
+		names = append(names, name)
+		for _, child := range currFolder.Children {
+			plan(child)
+		}
+	}
+	plan(srcFolder)
+	return names
+}
+
+// Hands out previously recorded copy names in order
+func recordedNaming(names []string) NamingFunc {
+	next := 0
+	return func(name string, _ func(string) bool) string {
+		if next >= len(names) {
+			return name
+		}
+		next++
+		return names[next-1]
+	}
+}
+
+// Returned for a record written by a newer version, skipping it would lose the change
+var errUnknownLogOp = errors.New("unknown operation")
+
+// Applies a logged mutation to d
+func applyLogRecord(d IDriver, rec logRecord) error {
+	var err error
+	switch rec.Op {
+	case opCreate:
+		_, err = d.CreateFolder(rec.OrgID, rec.Dst, rec.Name)
+	case opMove:
+		if rec.OrgID == uuid.Nil {
+			_, err = d.MoveFolder(rec.Name, rec.Dst)
+		} else {
+			_, err = d.MoveFolderInOrg(rec.OrgID, rec.Name, rec.Dst)
+		}
+	case opRename:
+		_, err = d.RenameFolder(rec.OrgID, rec.Name, rec.NewName)
+	case opDelete:
+		_, err = d.DeleteFolder(rec.OrgID, rec.Name, DeleteOptions{Recursive: rec.Recursive})
+	case opCopy:
+		_, err = d.CopyFolder(rec.OrgID, rec.Name, rec.Dst, recordedNaming(rec.Names))
+	case opMoveAcrossOrgs:
+		_, err = d.MoveFolderAcrossOrgs(rec.OrgID, rec.Name, rec.DstOrgID, rec.Dst, rec.Policy)
+	case opCopyAcrossOrgs:
+		_, err = d.CopyFolderAcrossOrgs(rec.OrgID, rec.Name, rec.DstOrgID, rec.Dst, rec.Policy)
+	default:
+		err = fmt.Errorf("%w '%s'", errUnknownLogOp, rec.Op)
+	}
+	return err
+}
+
+func encodeLogRecord(rec logRecord) ([]byte, error) {
+	js, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+	line := fmt.Sprintf("%0*x %s\n", walChecksumHexWidth, crc32.ChecksumIEEE(js), js)
+	return []byte(line), nil
+}
+
+func decodeLogRecord(line []byte) (logRecord, error) {
+	rec := logRecord{}
+	if len(line) == 0 || line[len(line)-1] != '\n' {
+		return rec, fmt.Errorf("incomplete record")
+	}
+	line = line[:len(line)-1]
+	if len(line) < walChecksumHexWidth+1 || line[walChecksumHexWidth] != ' ' {
+		return rec, fmt.Errorf("malformed record")
+	}
+
+	checksum, err := strconv.ParseUint(string(line[:walChecksumHexWidth]), 16, 32)
+	if err != nil {
+		return rec, fmt.Errorf("malformed checksum: %w", err)
+	}
+	js := line[walChecksumHexWidth+1:]
+	if crc32.ChecksumIEEE(js) != uint32(checksum) {
+		return rec, fmt.Errorf("checksum mismatch")
+	}
+	if err := json.Unmarshal(js, &rec); err != nil {
+		return rec, err
+	}
+	return rec, nil
+}
@@ -0,0 +1,328 @@
+package folder_test
+
+import (
+	"bufio"
+	"fmt"
+	"hash/crc32"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+// opens a logged driver and applies a few mutations of every kind
+func openAndMutate(t *testing.T, snapshotPath string, orgId uuid.UUID, compactEvery int) *folder.LoggedDriver {
+	t.Helper()
+
+	driver, err := folder.OpenLoggedDriver(snapshotPath, compactEvery)
+	if err != nil {
+		t.Fatalf("OpenLoggedDriver() received unexpected error: %v", err)
+	}
+	if _, err := driver.CreateFolder(orgId, "", "alpha"); err != nil {
+		t.Fatalf("CreateFolder() received unexpected error: %v", err)
+	}
+	if _, err := driver.CreateFolder(orgId, "alpha", "bravo"); err != nil {
+		t.Fatalf("CreateFolder() received unexpected error: %v", err)
+	}
+	if _, err := driver.CreateFolder(orgId, "", "charlie"); err != nil {
+		t.Fatalf("CreateFolder() received unexpected error: %v", err)
+	}
+	if _, err := driver.MoveFolderInOrg(orgId, "bravo", "charlie"); err != nil {
+		t.Fatalf("MoveFolderInOrg() received unexpected error: %v", err)
+	}
+	if _, err := driver.RenameFolder(orgId, "charlie", "delta"); err != nil {
+		t.Fatalf("RenameFolder() received unexpected error: %v", err)
+	}
+	// random names must be replayed exactly as they were handed out
+	if _, err := driver.CopyFolder(orgId, "delta", "alpha", folder.CodenameNaming(rand.New(rand.NewSource(rand.Int63())))); err != nil {
+		t.Fatalf("CopyFolder() received unexpected error: %v", err)
+	}
+	if _, err := driver.DeleteFolder(orgId, "bravo", folder.DeleteOptions{}); err != nil {
+		t.Fatalf("DeleteFolder() received unexpected error: %v", err)
+	}
+	// failed operations are not kept in the log
+	if _, err := driver.CreateFolder(orgId, "", "alpha"); err == nil {
+		t.Fatalf("CreateFolder() with duplicate name expected an error but got none")
+	}
+	return driver
+}
+
+func Test_folder_LoggedDriver_Replay(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	snapshotPath := filepath.Join(t.TempDir(), "folders.json")
+
+	driver := openAndMutate(t, snapshotPath, orgId, 0)
+	want := driver.GetFoldersByOrgID(orgId)
+	if err := driver.Close(); err != nil {
+		t.Fatalf("Close() received unexpected error: %v", err)
+	}
+
+	// no snapshot is written without compaction, everything comes from the log
+	if _, err := os.Stat(snapshotPath); !os.IsNotExist(err) {
+		t.Errorf("snapshot exists before compaction, Stat() error = %v", err)
+	}
+
+	reopened, err := folder.OpenLoggedDriver(snapshotPath, 0)
+	if err != nil {
+		t.Fatalf("OpenLoggedDriver() received unexpected error: %v", err)
+	}
+	defer reopened.Close()
+
+	if got := reopened.GetFoldersByOrgID(orgId); !compareFolders(got, want) {
+		t.Errorf("GetFoldersByOrgID() after replay = %v, want %v", got, want)
+	}
+}
+
+func Test_folder_LoggedDriver_Recovery(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name      string
+		corrupt   func(t *testing.T, logPath string)
+		wantError bool
+	}{
+		{
+			name: "torn final record is discarded",
+			corrupt: func(t *testing.T, logPath string) {
+				appendToFile(t, logPath, `1234abcd {"seq":99,"op":"cre`)
+			},
+		},
+		{
+			name: "final record with a bad checksum is discarded",
+			corrupt: func(t *testing.T, logPath string) {
+				appendToFile(t, logPath, `00000000 {"seq":99,"op":"create","name":"echo"}`+"\n")
+			},
+		},
+		{
+			name: "rejected operation left in the log is skipped",
+			corrupt: func(t *testing.T, logPath string) {
+				// as if the process stopped before removing a failed duplicate create
+				appendRecord(t, logPath, `{"seq":99,"op":"create","org_id":"`+orgId.String()+`","name":"alpha"}`)
+			},
+		},
+		{
+			name: "record with an unknown operation is an error",
+			corrupt: func(t *testing.T, logPath string) {
+				appendRecord(t, logPath, `{"seq":99,"op":"archive","name":"alpha"}`)
+			},
+			wantError: true,
+		},
+		{
+			name: "corrupted record before the end of the log is an error",
+			corrupt: func(t *testing.T, logPath string) {
+				lines := readLines(t, logPath)
+				lines[2] = "00000000" + lines[2][8:]
+				writeLines(t, logPath, lines)
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			snapshotPath := filepath.Join(t.TempDir(), "folders.json")
+			driver := openAndMutate(t, snapshotPath, orgId, 0)
+			want := driver.GetFoldersByOrgID(orgId)
+			driver.Close()
+
+			tt.corrupt(t, snapshotPath+".wal")
+
+			reopened, err := folder.OpenLoggedDriver(snapshotPath, 0)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("OpenLoggedDriver() error = %v, expected error presence: %v", err, tt.wantError)
+			}
+			if tt.wantError {
+				return
+			}
+			defer reopened.Close()
+
+			if got := reopened.GetFoldersByOrgID(orgId); !compareFolders(got, want) {
+				t.Errorf("GetFoldersByOrgID() after recovery = %v, want %v", got, want)
+			}
+			// the log is usable again after recovery
+			if _, err := reopened.CreateFolder(orgId, "", "echo"); err != nil {
+				t.Errorf("CreateFolder() after recovery received unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func Test_folder_LoggedDriver_Compact(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	snapshotPath := filepath.Join(t.TempDir(), "folders.json")
+	logPath := snapshotPath + ".wal"
+
+	driver := openAndMutate(t, snapshotPath, orgId, 3)
+	want := driver.GetFoldersByOrgID(orgId)
+
+	// 7 successful mutations with compaction every 3 leaves 1 record after the header
+	if lines := readLines(t, logPath); len(lines) != 2 {
+		t.Errorf("log holds %d lines, want 2", len(lines))
+	}
+
+	// keep the pre-compaction log to simulate a crash between writing the snapshot and resetting the log
+	staleLog := readLines(t, logPath)
+	if err := driver.Compact(); err != nil {
+		t.Fatalf("Compact() received unexpected error: %v", err)
+	}
+	driver.Close()
+
+	// the snapshot uses the same format as sample.json
+	snapshot, err := folder.NewFileStore(snapshotPath).Load()
+	if err != nil {
+		t.Fatalf("Load() on snapshot received unexpected error: %v", err)
+	}
+	if !compareFolders(snapshot, want) {
+		t.Errorf("snapshot = %v, want %v", snapshot, want)
+	}
+
+	writeLines(t, logPath, staleLog)
+	reopened, err := folder.OpenLoggedDriver(snapshotPath, 0)
+	if err != nil {
+		t.Fatalf("OpenLoggedDriver() with stale log received unexpected error: %v", err)
+	}
+	defer reopened.Close()
+	if got := reopened.GetFoldersByOrgID(orgId); !compareFolders(got, want) {
+		t.Errorf("GetFoldersByOrgID() with stale log = %v, want %v", got, want)
+	}
+}
+
+func Test_folder_LoggedDriver_CompactFailure(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	snapshotPath := filepath.Join(t.TempDir(), "folders.json")
+
+	driver, err := folder.OpenLoggedDriver(snapshotPath, 1)
+	if err != nil {
+		t.Fatalf("OpenLoggedDriver() received unexpected error: %v", err)
+	}
+	defer driver.Close()
+
+	// a non-empty directory in place of the snapshot makes every compaction fail
+	if err := os.MkdirAll(filepath.Join(snapshotPath, "blocker"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := driver.CreateFolder(orgId, "", "alpha"); err != nil {
+		t.Fatalf("CreateFolder() with failing compaction received unexpected error: %v", err)
+	}
+	if lines := readLines(t, snapshotPath+".wal"); len(lines) != 2 {
+		t.Errorf("log holds %d lines after failed compaction, want 2", len(lines))
+	}
+
+	// the next write retries the compaction and the snapshot picks up both records
+	if err := os.RemoveAll(snapshotPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := driver.CreateFolder(orgId, "alpha", "bravo"); err != nil {
+		t.Fatalf("CreateFolder() received unexpected error: %v", err)
+	}
+	snapshot, err := folder.NewFileStore(snapshotPath).Load()
+	if err != nil {
+		t.Fatalf("Load() on snapshot received unexpected error: %v", err)
+	}
+	if want := driver.GetFoldersByOrgID(orgId); len(want) != 2 || !compareFolders(snapshot, want) {
+		t.Errorf("snapshot = %v, want %v", snapshot, want)
+	}
+}
+
+func Test_folder_LoggedDriver_LogResetFailure(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	snapshotPath := filepath.Join(t.TempDir(), "folders.json")
+	logPath := snapshotPath + ".wal"
+
+	driver, err := folder.OpenLoggedDriver(snapshotPath, 2)
+	if err != nil {
+		t.Fatalf("OpenLoggedDriver() received unexpected error: %v", err)
+	}
+	if _, err := driver.CreateFolder(orgId, "", "alpha"); err != nil {
+		t.Fatalf("CreateFolder() received unexpected error: %v", err)
+	}
+
+	// the open log keeps working, but a non-empty directory in its place makes the
+	// compaction write the snapshot and then fail to reset the log
+	if err := os.Remove(logPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(logPath, "blocker"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := driver.CreateFolder(orgId, "alpha", "bravo"); err != nil {
+		t.Fatalf("CreateFolder() that reached the snapshot received unexpected error: %v", err)
+	}
+	// nothing may be acknowledged that the next open would discard
+	if _, err := driver.CreateFolder(orgId, "", "charlie"); err == nil {
+		t.Errorf("CreateFolder() after a failed log reset expected an error but got none")
+	}
+	if err := driver.Compact(); err == nil {
+		t.Errorf("Compact() after a failed log reset expected an error but got none")
+	}
+	driver.Close()
+
+	if err := os.RemoveAll(logPath); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := folder.OpenLoggedDriver(snapshotPath, 0)
+	if err != nil {
+		t.Fatalf("OpenLoggedDriver() received unexpected error: %v", err)
+	}
+	defer reopened.Close()
+	got := reopened.GetFoldersByOrgID(orgId)
+	if len(got) != 2 {
+		t.Errorf("GetFoldersByOrgID() after reopen = %v, want alpha and alpha.bravo", got)
+	}
+	if _, err := reopened.GetFolderByPath(orgId, "alpha.bravo"); err != nil {
+		t.Errorf("GetFolderByPath() after reopen received unexpected error: %v", err)
+	}
+}
+
+func appendToFile(t *testing.T, path string, s string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(s); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// appends js to the log as a record with a valid checksum
+func appendRecord(t *testing.T, logPath string, js string) {
+	t.Helper()
+	appendToFile(t, logPath, fmt.Sprintf("%08x %s\n", crc32.ChecksumIEEE([]byte(js)), js))
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return lines
+}
+
+func writeLines(t *testing.T, path string, lines []string) {
+	t.Helper()
+	s := ""
+	for _, line := range lines {
+		s += line + "\n"
+	}
+	if err := os.WriteFile(path, []byte(s), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,152 @@
+// Package ltree implements the PostgreSQL ltree operators and label functions
+// over dot separated label paths such as Folder.Paths.
+//
+// Positions are zero based and negative positions count back from the end of
+// the path, matching the semantics documented for the ltree extension.
+package ltree
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidPosition is returned when an offset or length falls outside a path.
+var ErrInvalidPosition = errors.New("ltree: invalid positions")
+
+// Labels splits a path into its labels, the empty path has no labels.
+func Labels(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+// Join builds a path from labels.
+func Join(labels ...string) string {
+	return strings.Join(labels, ".")
+}
+
+// NLevel returns the number of labels in path.
+func NLevel(path string) int {
+	if path == "" {
+		return 0
+	}
+	return strings.Count(path, ".") + 1
+}
+
+// Parent returns path without its last label, or "" for a root path.
+func Parent(path string) string {
+	lastDot := strings.LastIndex(path, ".")
+	if lastDot == -1 {
+		return ""
+	}
+	return path[:lastDot]
+}
+
+// IsAncestor reports whether a is an ancestor of b or equal to it (a @> b).
+func IsAncestor(a, b string) bool {
+	return a == "" || a == b || strings.HasPrefix(b, a+".")
+}
+
+// IsDescendant reports whether a is a descendant of b or equal to it (a <@ b).
+func IsDescendant(a, b string) bool {
+	return IsAncestor(b, a)
+}
+
+// Subltree returns the labels of path from position start up to, but not
+// including, end. subltree('Top.Child1.Child2', 1, 2) is 'Child1'.
+func Subltree(path string, start, end int) (string, error) {
+	labels := Labels(path)
+	if start < 0 || end < 0 || start >= len(labels) || start > end {
+		return "", fmt.Errorf("%w: subltree(%q, %d, %d)", ErrInvalidPosition, path, start, end)
+	}
+	if end > len(labels) {
+		end = len(labels)
+	}
+	return Join(labels[start:end]...), nil
+}
+
+// Subpath returns length labels of path starting at offset. A negative offset
+// starts that far from the end of the path, a negative length leaves that many
+// labels off the end. subpath('Top.Child1.Child2', 0, 2) is 'Top.Child1'.
+func Subpath(path string, offset, length int) (string, error) {
+	n := NLevel(path)
+	start := offset
+	if start < 0 {
+		start = n + start
+	}
+	end := start + length
+	if length < 0 {
+		end = n + length
+	}
+	s, err := Subltree(path, start, end)
+	if err != nil {
+		return "", fmt.Errorf("%w: subpath(%q, %d, %d)", ErrInvalidPosition, path, offset, length)
+	}
+	return s, nil
+}
+
+// Index returns the position of the first occurrence of sub in path, searching
+// from offset, or -1 if sub does not occur. A negative offset starts the search
+// that far from the end of the path.
+func Index(path, sub string, offset int) int {
+	labels, subLabels := Labels(path), Labels(sub)
+	start := offset
+	if start < 0 {
+		start = len(labels) + start
+		if start < 0 {
+			start = 0
+		}
+	}
+	for i := start; i <= len(labels)-len(subLabels); i++ {
+		if equalLabels(labels[i:i+len(subLabels)], subLabels) {
+			return i
+		}
+	}
+	return -1
+}
+
+// CommonPrefix returns the longest path that is an ancestor of, or equal to,
+// every given path.
+func CommonPrefix(paths ...string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	prefix := Labels(paths[0])
+	for _, path := range paths[1:] {
+		labels := Labels(path)
+		n := 0
+		for n < len(prefix) && n < len(labels) && prefix[n] == labels[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return Join(prefix...)
+}
+
+// LCA returns the longest common ancestor of paths. Like ltree's lca, a path is
+// not its own ancestor, so lca('1.2.3', '1.2.3.4.5.6') is '1.2'.
+func LCA(paths ...string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	shortest := NLevel(paths[0])
+	for _, path := range paths[1:] {
+		shortest = min(shortest, NLevel(path))
+	}
+	prefix := Labels(CommonPrefix(paths...))
+	if len(prefix) >= shortest {
+		prefix = prefix[:max(shortest-1, 0)]
+	}
+	return Join(prefix...)
+}
+
+func equalLabels(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+package ltree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/ltree"
+)
+
+func Test_ltree_NLevel(t *testing.T) {
+	tests := []struct {
+		path string
+		want int
+	}{
+		{path: "", want: 0},
+		{path: "Top", want: 1},
+		{path: "Top.Child1.Child2", want: 3},
+	}
+
+	for _, tt := range tests {
+		if got := ltree.NLevel(tt.path); got != tt.want {
+			t.Errorf("NLevel(%q) = %d, want %d", tt.path, got, tt.want)
+		}
+	}
+}
+
+func Test_ltree_Parent(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "", want: ""},
+		{path: "Top", want: ""},
+		{path: "Top.Child1.Child2", want: "Top.Child1"},
+	}
+
+	for _, tt := range tests {
+		if got := ltree.Parent(tt.path); got != tt.want {
+			t.Errorf("Parent(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func Test_ltree_IsAncestor(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "Top", b: "Top.Science", want: true},
+		{a: "Top", b: "Top", want: true},
+		{a: "", b: "Top.Science", want: true},
+		{a: "Top.Science", b: "Top", want: false},
+		{a: "Top.Sci", b: "Top.Science", want: false},
+		{a: "Top", b: "Topology", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := ltree.IsAncestor(tt.a, tt.b); got != tt.want {
+			t.Errorf("IsAncestor(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := ltree.IsDescendant(tt.b, tt.a); got != tt.want {
+			t.Errorf("IsDescendant(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func Test_ltree_Subltree(t *testing.T) {
+	tests := []struct {
+		path       string
+		start, end int
+		want       string
+		wantErr    bool
+	}{
+		{path: "Top.Child1.Child2", start: 1, end: 2, want: "Child1"},
+		{path: "Top.Child1.Child2", start: 0, end: 3, want: "Top.Child1.Child2"},
+		{path: "Top.Child1.Child2", start: 1, end: 10, want: "Child1.Child2"},
+		{path: "Top.Child1.Child2", start: 1, end: 1, want: ""},
+		{path: "Top.Child1.Child2", start: 3, end: 4, wantErr: true},
+		{path: "Top.Child1.Child2", start: 2, end: 1, wantErr: true},
+		{path: "Top.Child1.Child2", start: -1, end: 1, wantErr: true},
+		{path: "", start: 0, end: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ltree.Subltree(tt.path, tt.start, tt.end)
+		if tt.wantErr {
+			if !errors.Is(err, ltree.ErrInvalidPosition) {
+				t.Errorf("Subltree(%q, %d, %d) error = %v, want %v", tt.path, tt.start, tt.end, err, ltree.ErrInvalidPosition)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Subltree(%q, %d, %d) = %q, %v, want %q", tt.path, tt.start, tt.end, got, err, tt.want)
+		}
+	}
+}
+
+func Test_ltree_Subpath(t *testing.T) {
+	tests := []struct {
+		path           string
+		offset, length int
+		want           string
+		wantErr        bool
+	}{
+		{path: "Top.Child1.Child2", offset: 0, length: 2, want: "Top.Child1"},
+		{path: "Top.Child1.Child2", offset: 1, length: 5, want: "Child1.Child2"},
+		{path: "Top.Child1.Child2", offset: -2, length: 1, want: "Child1"},
+		{path: "Top.Child1.Child2", offset: 0, length: -1, want: "Top.Child1"},
+		{path: "Top.Child1.Child2", offset: -1, length: -1, want: ""},
+		{path: "Top.Child1.Child2", offset: -4, length: 1, wantErr: true},
+		{path: "Top.Child1.Child2", offset: 3, length: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ltree.Subpath(tt.path, tt.offset, tt.length)
+		if tt.wantErr {
+			if !errors.Is(err, ltree.ErrInvalidPosition) {
+				t.Errorf("Subpath(%q, %d, %d) error = %v, want %v", tt.path, tt.offset, tt.length, err, ltree.ErrInvalidPosition)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Subpath(%q, %d, %d) = %q, %v, want %q", tt.path, tt.offset, tt.length, got, err, tt.want)
+		}
+	}
+}
+
+func Test_ltree_Index(t *testing.T) {
+	tests := []struct {
+		path, sub string
+		offset    int
+		want      int
+	}{
+		{path: "0.1.2.3.5.4.5.6.8.5.6.8", sub: "5.6", offset: 0, want: 6},
+		{path: "0.1.2.3.5.4.5.6.8.5.6.8", sub: "5.6", offset: 7, want: 9},
+		{path: "0.1.2.3.5.4.5.6.8.5.6.8", sub: "5.6", offset: -4, want: 9},
+		{path: "0.1.2.3.5.4.5.6.8.5.6.8", sub: "5.6", offset: -20, want: 6},
+		{path: "0.1.2.3.5.4.5.6.8.5.6.8", sub: "5.7", offset: 0, want: -1},
+		{path: "Top.Child1", sub: "Top.Child1.Child2", offset: 0, want: -1},
+	}
+
+	for _, tt := range tests {
+		if got := ltree.Index(tt.path, tt.sub, tt.offset); got != tt.want {
+			t.Errorf("Index(%q, %q, %d) = %d, want %d", tt.path, tt.sub, tt.offset, got, tt.want)
+		}
+	}
+}
+
+func Test_ltree_LCA(t *testing.T) {
+	tests := []struct {
+		paths      []string
+		want       string
+		wantPrefix string
+	}{
+		{paths: []string{"1.2.3", "1.2.3.4.5.6"}, want: "1.2", wantPrefix: "1.2.3"},
+		{paths: []string{"1.2.3", "1.2.4"}, want: "1.2", wantPrefix: "1.2"},
+		{paths: []string{"1.2.3"}, want: "1.2", wantPrefix: "1.2.3"},
+		{paths: []string{"1.2", "3.4"}, want: "", wantPrefix: ""},
+		{paths: []string{"1.2.3", "1.2.5", "1.7"}, want: "1", wantPrefix: "1"},
+		{paths: nil, want: "", wantPrefix: ""},
+	}
+
+	for _, tt := range tests {
+		if got := ltree.LCA(tt.paths...); got != tt.want {
+			t.Errorf("LCA(%q) = %q, want %q", tt.paths, got, tt.want)
+		}
+		if got := ltree.CommonPrefix(tt.paths...); got != tt.wantPrefix {
+			t.Errorf("CommonPrefix(%q) = %q, want %q", tt.paths, got, tt.wantPrefix)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+// Package server exposes a folder.IDriver as a REST API over HTTP with JSON bodies.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+// Routes
+//
+//	GET    /orgs/{org}/folders                   all folders in an org
+//	POST   /orgs/{org}/folders                   create a folder, body {"name", "parent"}
+//	GET    /orgs/{org}/folders/{name}/children   descendents of a folder, ?depth=n limits the levels and
+//	                                             ?order=preorder|breadthfirst sets the order
+//	GET    /orgs/{org}/folders/{name}/tree       a folder and its descendents as nested JSON
+//	GET    /orgs/{org}/tree                      every folder in an org as nested JSON
+//	DELETE /orgs/{org}/folders/{name}            delete a folder, ?recursive=true deletes its subtree
+//	POST   /orgs/{org}/folders/{name}:move       move a folder, body {"dst", "dst_org_id", "policy"}
+//	POST   /orgs/{org}/folders/{name}:copy       copy a folder, body {"dst", "dst_org_id", "policy"}
+//	POST   /orgs/{org}/folders/{name}:rename     rename a folder, body {"name"}
+//	GET    /orgs/{org}/paths/{path}              the folder at an exact ltree path
+//	POST   /folders:move                         move by name with the org inferred, body {"name", "dst"}
+//
+// Errors are returned as {"error": {"code", "message"}}. The code not_flushed means the
+// change was applied but could not be saved, retrying it would apply it twice.
+type Server struct {
+	driver folder.IDriver
+	mux    *http.ServeMux
+}
+
+func New(driver folder.IDriver) *Server {
+	s := &Server{
+		driver: driver,
+		mux:    http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /orgs/{org}/folders", s.handleListFolders)
+	s.mux.HandleFunc("POST /orgs/{org}/folders", s.handleCreateFolder)
+	s.mux.HandleFunc("GET /orgs/{org}/folders/{name}/children", s.handleGetChildren)
+	s.mux.HandleFunc("GET /orgs/{org}/folders/{name}/tree", s.handleGetSubTree)
+	s.mux.HandleFunc("GET /orgs/{org}/tree", s.handleGetTree)
+	s.mux.HandleFunc("DELETE /orgs/{org}/folders/{name}", s.handleDeleteFolder)
+	// ServeMux wildcards must fill a whole segment, so "{name}:action" is split by hand
+	s.mux.HandleFunc("POST /orgs/{org}/folders/{action}", s.handleFolderAction)
+	s.mux.HandleFunc("GET /orgs/{org}/paths/{path}", s.handleGetByPath)
+	s.mux.HandleFunc("POST /folders:move", s.handleMoveByName)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Body of every error response
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type createRequest struct {
+	Name   string `json:"name"`
+	Parent string `json:"parent"`
+}
+
+type moveRequest struct {
+	Name string `json:"name"`
+	Dst  string `json:"dst"`
+	// DstOrgID moves or copies into another org, Policy resolves name collisions there
+	DstOrgID string `json:"dst_org_id"`
+	Policy   string `json:"policy"`
+}
+
+type renameRequest struct {
+	Name string `json:"name"`
+}
+
+func (s *Server) handleListFolders(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := orgFromRequest(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.driver.GetFoldersByOrgID(orgID))
+}
+
+func (s *Server) handleCreateFolder(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := orgFromRequest(w, r)
+	if !ok {
+		return
+	}
+	req := createRequest{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	created, err := s.driver.CreateFolder(orgID, req.Parent, req.Name)
+	if err != nil {
+		writeDriverError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *Server) handleGetChildren(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := orgFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	depth := 0
+	if v := query.Get("depth"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("invalid depth '%s'", v))
+			return
+		}
+		depth = n
+	}
+	order := folder.PreOrder
+	if v := query.Get("order"); v != "" {
+		var err error
+		if order, err = folder.ParseTraversalOrder(v); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+			return
+		}
+	}
+
+	children, err := s.driver.GetChildFolders(orgID, r.PathValue("name"), depth, order)
+	if err != nil {
+		writeDriverError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, children)
+}
+
+func (s *Server) handleGetSubTree(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := orgFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	node, err := s.driver.SubTree(orgID, r.PathValue("name"))
+	if err != nil {
+		writeDriverError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, node)
+}
+
+func (s *Server) handleGetTree(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := orgFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	nodes, err := s.driver.BuildTree(orgID)
+	if err != nil {
+		writeDriverError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, nodes)
+}
+
+func (s *Server) handleDeleteFolder(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := orgFromRequest(w, r)
+	if !ok {
+		return
+	}
+	opts := folder.DeleteOptions{Recursive: r.URL.Query().Get("recursive") == "true"}
+
+	removed, err := s.driver.DeleteFolder(orgID, r.PathValue("name"), opts)
+	if err != nil {
+		writeDriverError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, removed)
+}
+
+func (s *Server) handleFolderAction(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := orgFromRequest(w, r)
+	if !ok {
+		return
+	}
+	// folder names may contain ':' themselves, the action follows the last one
+	nameAction := r.PathValue("action")
+	i := strings.LastIndexByte(nameAction, ':')
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+		return
+	}
+	name, action := nameAction[:i], nameAction[i+1:]
+
+	switch action {
+	case "move", "copy":
+		req := moveRequest{}
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		s.moveOrCopy(w, orgID, name, action, req)
+	case "rename":
+		req := renameRequest{}
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		renamed, err := s.driver.RenameFolder(orgID, name, req.Name)
+		if err != nil {
+			writeDriverError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, renamed)
+	default:
+		writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("unknown folder action '%s'", action))
+	}
+}
+
+// Moves or copies within orgID, or into req.DstOrgID when it is set
+func (s *Server) moveOrCopy(w http.ResponseWriter, orgID uuid.UUID, name string, action string, req moveRequest) {
+	var res []folder.Folder
+	var err error
+
+	if req.DstOrgID == "" {
+		if action == "move" {
+			res, err = s.driver.MoveFolderInOrg(orgID, name, req.Dst)
+		} else {
+			res, err = s.driver.CopyFolder(orgID, name, req.Dst, nil)
+		}
+	} else {
+		dstOrgID, parseErr := uuid.FromString(req.DstOrgID)
+		if parseErr != nil {
+			writeError(w, http.StatusBadRequest, "invalid_org_id", fmt.Sprintf("invalid dst_org_id '%s'", req.DstOrgID))
+			return
+		}
+		policy := folder.ConflictFail
+		if req.Policy != "" {
+			if policy, parseErr = folder.ParseConflictPolicy(req.Policy); parseErr != nil {
+				writeError(w, http.StatusBadRequest, "invalid_request", parseErr.Error())
+				return
+			}
+		}
+		if action == "move" {
+			res, err = s.driver.MoveFolderAcrossOrgs(orgID, name, dstOrgID, req.Dst, policy)
+		} else {
+			res, err = s.driver.CopyFolderAcrossOrgs(orgID, name, dstOrgID, req.Dst, policy)
+		}
+	}
+
+	if err != nil {
+		writeDriverError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) handleGetByPath(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := orgFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	found, err := s.driver.GetFolderByPath(orgID, r.PathValue("path"))
+	if err != nil {
+		writeDriverError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, found)
+}
+
+func (s *Server) handleMoveByName(w http.ResponseWriter, r *http.Request) {
+	req := moveRequest{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	moved, err := s.driver.MoveFolder(req.Name, req.Dst)
+	if err != nil {
+		writeDriverError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, moved)
+}
+
+// Parses the {org} path segment, writing a 400 if it is not a valid uuid
+func orgFromRequest(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	orgID, err := uuid.FromString(r.PathValue("org"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_org_id", fmt.Sprintf("invalid org id '%s'", r.PathValue("org")))
+		return uuid.Nil, false
+	}
+	return orgID, true
+}
+
+// Decodes a JSON request body into v, writing a 400 if it is malformed
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+// Maps a driver error to a status code through the folder package's sentinel errors
+func writeDriverError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, folder.ErrNotFlushed):
+		writeError(w, http.StatusInternalServerError, "not_flushed", err.Error())
+	case errors.Is(err, folder.ErrFolderNotFound), errors.Is(err, folder.ErrWrongOrg):
+		writeError(w, http.StatusNotFound, "not_found", err.Error())
+	case errors.Is(err, folder.ErrDuplicateName), errors.Is(err, folder.ErrAmbiguousName),
+		errors.Is(err, folder.ErrCycle), errors.Is(err, folder.ErrHasChildren):
+		writeError(w, http.StatusConflict, "conflict", err.Error())
+	case errors.Is(err, folder.ErrInvalidOrgID):
+		writeError(w, http.StatusBadRequest, "invalid_org_id", err.Error())
+	case errors.Is(err, folder.ErrInvalidName):
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "internal", err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, errorResponse{Error: errorBody{Code: code, Message: message}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
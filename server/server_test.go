@@ -0,0 +1,405 @@
+package server_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/georgechieng-sc/interns-2022/server"
+	"github.com/gofrs/uuid"
+)
+
+func TestServer(t *testing.T) {
+	orgId1 := uuid.Must(uuid.NewV4())
+	orgId2 := uuid.Must(uuid.NewV4())
+	org1 := orgId1.String()
+	org2 := orgId2.String()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		// names of the returned folders, in any order
+		wantNames []string
+		wantCode  string
+	}{
+		// Functionalities
+		{
+			name:       "list folders in an org",
+			method:     http.MethodGet,
+			path:       "/orgs/" + org2 + "/folders",
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"foxtrot"},
+		},
+		{
+			name:       "get all child folders",
+			method:     http.MethodGet,
+			path:       "/orgs/" + org1 + "/folders/alpha/children",
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"bravo", "charlie", "delta"},
+		},
+		{
+			name:       "get direct child folders",
+			method:     http.MethodGet,
+			path:       "/orgs/" + org1 + "/folders/alpha/children?depth=1&order=breadthfirst",
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"bravo", "delta"},
+		},
+		{
+			name:       "get nested tree of an org",
+			method:     http.MethodGet,
+			path:       "/orgs/" + org1 + "/tree",
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"alpha"},
+		},
+		{
+			name:       "get nested subtree of a folder",
+			method:     http.MethodGet,
+			path:       "/orgs/" + org1 + "/folders/bravo/tree",
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"bravo"},
+		},
+		{
+			name:       "create folder",
+			method:     http.MethodPost,
+			path:       "/orgs/" + org1 + "/folders",
+			body:       `{"name": "hotel", "parent": "delta"}`,
+			wantStatus: http.StatusCreated,
+			wantNames:  []string{"hotel"},
+		},
+		{
+			name:       "move folder within an org",
+			method:     http.MethodPost,
+			path:       "/orgs/" + org1 + "/folders/bravo:move",
+			body:       `{"dst": "delta"}`,
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"alpha", "bravo", "charlie", "delta", "foxtrot"},
+		},
+		{
+			name:       "move folder into another org",
+			method:     http.MethodPost,
+			path:       "/orgs/" + org1 + "/folders/bravo:move",
+			body:       `{"dst": "foxtrot", "dst_org_id": "` + org2 + `", "policy": "fail"}`,
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"alpha", "bravo", "charlie", "delta", "foxtrot"},
+		},
+		{
+			name:       "copy folder within an org",
+			method:     http.MethodPost,
+			path:       "/orgs/" + org1 + "/folders/bravo:copy",
+			body:       `{"dst": "delta"}`,
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"bravo_copy", "charlie_copy"},
+		},
+		{
+			name:       "rename folder",
+			method:     http.MethodPost,
+			path:       "/orgs/" + org1 + "/folders/charlie:rename",
+			body:       `{"name": "hotel"}`,
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"hotel"},
+		},
+		{
+			name:       "recursively delete folder",
+			method:     http.MethodDelete,
+			path:       "/orgs/" + org1 + "/folders/bravo?recursive=true",
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"bravo", "charlie"},
+		},
+		{
+			name:       "get folder by path",
+			method:     http.MethodGet,
+			path:       "/orgs/" + org1 + "/paths/alpha.bravo.charlie",
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"charlie"},
+		},
+		{
+			name:       "move folder with the org inferred",
+			method:     http.MethodPost,
+			path:       "/folders:move",
+			body:       `{"name": "charlie", "dst": "delta"}`,
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"alpha", "bravo", "charlie", "delta", "foxtrot"},
+		},
+
+		// error checking
+		{
+			name:       "invalid org id",
+			method:     http.MethodGet,
+			path:       "/orgs/not-a-uuid/folders",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_org_id",
+		},
+		{
+			name:       "children of a folder that does not exist",
+			method:     http.MethodGet,
+			path:       "/orgs/" + org1 + "/folders/invalid_folder/children",
+			wantStatus: http.StatusNotFound,
+			wantCode:   "not_found",
+		},
+		{
+			name:       "children of a folder in a different org",
+			method:     http.MethodGet,
+			path:       "/orgs/" + org1 + "/folders/foxtrot/children",
+			wantStatus: http.StatusNotFound,
+			wantCode:   "not_found",
+		},
+		{
+			name:       "children with a negative depth",
+			method:     http.MethodGet,
+			path:       "/orgs/" + org1 + "/folders/alpha/children?depth=-1",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_request",
+		},
+		{
+			name:       "children with an unknown order",
+			method:     http.MethodGet,
+			path:       "/orgs/" + org1 + "/folders/alpha/children?order=sideways",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_request",
+		},
+		{
+			name:       "create folder with a duplicate name",
+			method:     http.MethodPost,
+			path:       "/orgs/" + org1 + "/folders",
+			body:       `{"name": "bravo"}`,
+			wantStatus: http.StatusConflict,
+			wantCode:   "conflict",
+		},
+		{
+			name:       "create folder with an invalid name",
+			method:     http.MethodPost,
+			path:       "/orgs/" + org1 + "/folders",
+			body:       `{"name": "hotel.india"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_request",
+		},
+		{
+			name:       "move folder into its own child",
+			method:     http.MethodPost,
+			path:       "/orgs/" + org1 + "/folders/bravo:move",
+			body:       `{"dst": "charlie"}`,
+			wantStatus: http.StatusConflict,
+			wantCode:   "conflict",
+		},
+		{
+			name:       "delete folder with children non-recursively",
+			method:     http.MethodDelete,
+			path:       "/orgs/" + org1 + "/folders/bravo",
+			wantStatus: http.StatusConflict,
+			wantCode:   "conflict",
+		},
+		{
+			name:       "malformed request body",
+			method:     http.MethodPost,
+			path:       "/orgs/" + org1 + "/folders",
+			body:       `{"name":`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_request",
+		},
+		{
+			name:       "unknown conflict policy",
+			method:     http.MethodPost,
+			path:       "/orgs/" + org1 + "/folders/bravo:move",
+			body:       `{"dst": "foxtrot", "dst_org_id": "` + org2 + `", "policy": "overwrite"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_request",
+		},
+		{
+			name:       "unknown folder action",
+			method:     http.MethodPost,
+			path:       "/orgs/" + org1 + "/folders/bravo:archive",
+			body:       `{}`,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "not_found",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := folder.NewDriver([]folder.Folder{
+				{Name: "alpha", Paths: "alpha", OrgId: orgId1},
+				{Name: "bravo", Paths: "alpha.bravo", OrgId: orgId1},
+				{Name: "charlie", Paths: "alpha.bravo.charlie", OrgId: orgId1},
+				{Name: "delta", Paths: "alpha.delta", OrgId: orgId1},
+				{Name: "foxtrot", Paths: "foxtrot", OrgId: orgId2},
+			})
+			if err != nil {
+				t.Fatalf("driver failed to initialise - %v", err)
+			}
+			ts := httptest.NewServer(server.New(driver))
+			defer ts.Close()
+
+			req, err := http.NewRequest(tt.method, ts.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("%s %s status = %d, want %d, body %s", tt.method, tt.path, resp.StatusCode, tt.wantStatus, body)
+			}
+			if got := resp.Header.Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %s, want application/json", got)
+			}
+
+			if tt.wantCode != "" {
+				errResp := struct {
+					Error struct {
+						Code    string `json:"code"`
+						Message string `json:"message"`
+					} `json:"error"`
+				}{}
+				if err := json.Unmarshal(body, &errResp); err != nil {
+					t.Fatalf("error body is not JSON: %v, body %s", err, body)
+				}
+				if errResp.Error.Code != tt.wantCode || errResp.Error.Message == "" {
+					t.Errorf("error = %+v, want code %s with a message", errResp.Error, tt.wantCode)
+				}
+				return
+			}
+
+			if got := folderNames(t, body); strings.Join(got, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("%s %s returned folders %v, want %v", tt.method, tt.path, got, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestServer_NameWithColon(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	org := orgId.String()
+
+	tests := []struct {
+		name      string
+		path      string
+		body      string
+		wantNames []string
+	}{
+		{
+			name:      "rename",
+			path:      "/orgs/" + org + "/folders/alpha:beta:rename",
+			body:      `{"name": "charlie"}`,
+			wantNames: []string{"charlie"},
+		},
+		{
+			name:      "copy",
+			path:      "/orgs/" + org + "/folders/alpha:beta:copy",
+			body:      `{"dst": "delta"}`,
+			wantNames: []string{"alpha:beta_copy"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := folder.NewDriver([]folder.Folder{
+				{Name: "alpha:beta", Paths: "alpha:beta", OrgId: orgId},
+				{Name: "delta", Paths: "delta", OrgId: orgId},
+			})
+			if err != nil {
+				t.Fatalf("driver failed to initialise - %v", err)
+			}
+			ts := httptest.NewServer(server.New(driver))
+			defer ts.Close()
+
+			resp, err := http.Post(ts.URL+tt.path, "application/json", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("POST %s status = %d, want %d, body %s", tt.path, resp.StatusCode, http.StatusOK, body)
+			}
+			if got := folderNames(t, body); strings.Join(got, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("POST %s returned folders %v, want %v", tt.path, got, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestServer_NotFlushed(t *testing.T) {
+	orgId := uuid.Must(uuid.NewV4())
+	dataFile := filepath.Join(t.TempDir(), "folders.json")
+
+	driver, err := folder.OpenStoredDriver(folder.NewFileStore(dataFile), true)
+	if err != nil {
+		t.Fatalf("OpenStoredDriver() received unexpected error: %v", err)
+	}
+	ts := httptest.NewServer(server.New(driver))
+	defer ts.Close()
+
+	// a non-empty directory in place of the data file makes every save fail
+	if err := os.MkdirAll(filepath.Join(dataFile, "blocker"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Post(ts.URL+"/orgs/"+orgId.String()+"/folders", "application/json", strings.NewReader(`{"name": "alpha"}`))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	errResp := struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError || errResp.Error.Code != "not_flushed" {
+		t.Errorf("create with failing save = %d %s, want %d not_flushed", resp.StatusCode, errResp.Error.Code, http.StatusInternalServerError)
+	}
+	// the change was applied even though it was not saved
+	if _, err := driver.GetFolderByPath(orgId, "alpha"); err != nil {
+		t.Errorf("GetFolderByPath() after failed save received unexpected error: %v", err)
+	}
+}
+
+// sorted names of the folder or folders in a JSON response body
+func folderNames(t *testing.T, body []byte) []string {
+	t.Helper()
+
+	folders := []folder.Folder{}
+	if strings.HasPrefix(strings.TrimSpace(string(body)), "{") {
+		single := folder.Folder{}
+		if err := json.Unmarshal(body, &single); err != nil {
+			t.Fatalf("response is not a folder: %v, body %s", err, body)
+		}
+		folders = append(folders, single)
+	} else if err := json.Unmarshal(body, &folders); err != nil {
+		t.Fatalf("response is not a folder list: %v, body %s", err, body)
+	}
+
+	names := []string{}
+	for _, f := range folders {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+	return names
+}